@@ -2,62 +2,126 @@ package auth
 
 import (
 	"context"
+	"crypto/rand"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
-	"os"
+	"net/url"
+	"unicode/utf8"
 
 	"github.com/gin-gonic/gin"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 
+	"main/config"
 	"main/db"
 )
 
+// Google 사용자 정보 기본 주소
+const defaultGoogleUserInfoURL = "https://www.googleapis.com/oauth2/v2/userinfo"
+
+// OAuth state를 브라우저에 묶어두는 쿠키 이름
+const oauthStateCookie = "oauth_state"
+
+// 다른 계정에 이미 연결된 Google 계정
+var ErrGoogleAccountLinked = errors.New("이미 다른 계정에 연결된 Google 계정입니다")
+
 type GoogleUser struct {
 	Id    string `json:"id"`
 	Email string `json:"email"`
 	Name  string `json:"name"`
 }
 
-var googleOauthConfig *oauth2.Config
-
-func SetupGoogleOAuth() {
-	clientID := os.Getenv("GOOGLE_CLIENT_ID")
-	clientSecret := os.Getenv("GOOGLE_CLIENT_SECRET")
+var (
+	googleOauthConfig  *oauth2.Config
+	googleUserInfoURL  string
+	googleLoginSuccess string // 로그인 성공 후 세션 토큰을 넘겨줄 주소 (비어있으면 JSON 응답)
+	oauthStates        = newOAuthStateStore()
+)
 
-	if clientID == "" || clientSecret == "" {
-		fmt.Println("❌ 환경변수 GOOGLE_CLIENT_ID 또는 GOOGLE_CLIENT_SECRET 누락됨")
+func SetupGoogleOAuth(cfg config.OAuthConfig) {
+	endpoint := google.Endpoint
+	if cfg.GoogleAuthURL != "" {
+		endpoint.AuthURL = cfg.GoogleAuthURL
+	}
+	if cfg.GoogleTokenURL != "" {
+		endpoint.TokenURL = cfg.GoogleTokenURL
 	}
 
 	googleOauthConfig = &oauth2.Config{
-		RedirectURL:  "http://localhost:8081/google/oauth2",
-		ClientID:     clientID,
-		ClientSecret: clientSecret,
+		RedirectURL:  cfg.GoogleRedirectURL,
+		ClientID:     cfg.GoogleClientID,
+		ClientSecret: cfg.GoogleClientSecret,
 		Scopes: []string{
 			"https://www.googleapis.com/auth/userinfo.email",
 			"https://www.googleapis.com/auth/userinfo.profile",
 		},
-		Endpoint: google.Endpoint,
+		Endpoint: endpoint,
+	}
+
+	googleUserInfoURL = cfg.GoogleUserInfoURL
+	if googleUserInfoURL == "" {
+		googleUserInfoURL = defaultGoogleUserInfoURL
 	}
+	googleLoginSuccess = cfg.LoginSuccessURL
 }
 
+// Google 로그인 시작 (state와 PKCE verifier를 만들고 Google로 이동)
+// ?token=<세션 토큰>을 붙이면 로그인한 계정에 Google 계정을 연결
 func GoogleLoginHandler(c *gin.Context) {
-	url := googleOauthConfig.AuthCodeURL("state-token")
-	c.Redirect(http.StatusTemporaryRedirect, url)
+	var linkAccountID string
+	if token := c.Query("token"); token != "" {
+		claims, err := VerifySessionToken(token)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "계정 연결 실패", "detail": err.Error()})
+			return
+		}
+		linkAccountID = claims.AccountID
+	}
+
+	verifier := oauth2.GenerateVerifier()
+	state, err := oauthStates.issue(verifier, linkAccountID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "state 생성 실패", "detail": err.Error()})
+		return
+	}
+
+	// state를 이 브라우저에 묶어 다른 브라우저에서 콜백을 이어받지 못하게 함
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oauthStateCookie, state, int(oauthStateTTL.Seconds()), "/", "", c.Request.TLS != nil, true)
+
+	authURL := googleOauthConfig.AuthCodeURL(state, oauth2.S256ChallengeOption(verifier))
+	c.Redirect(http.StatusTemporaryRedirect, authURL)
 }
 
 func GoogleCallbackHandler(c *gin.Context) {
+	// state 확인 (쿠키와 일치하고, 발급한 적 있으며, 아직 쓰지 않은 값이어야 함)
+	state := c.Query("state")
+	cookieState, _ := c.Cookie(oauthStateCookie)
+	c.SetCookie(oauthStateCookie, "", -1, "/", "", c.Request.TLS != nil, true)
+	if state == "" || state != cookieState {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 state 값입니다"})
+		return
+	}
+	pending := oauthStates.claim(state)
+	if pending == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "만료되었거나 이미 사용한 로그인 요청입니다"})
+		return
+	}
+
 	code := c.Query("code")
 
-	token, err := googleOauthConfig.Exchange(context.Background(), code)
+	token, err := googleOauthConfig.Exchange(context.Background(), code, oauth2.VerifierOption(pending.verifier))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "토큰 교환 실패", "detail": err.Error()})
 		return
 	}
 
 	client := googleOauthConfig.Client(context.Background(), token)
-	resp, err := client.Get("https://www.googleapis.com/oauth2/v2/userinfo")
+	resp, err := client.Get(googleUserInfoURL)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "사용자 정보 요청 실패", "detail": err.Error()})
 		return
@@ -69,20 +133,174 @@ func GoogleCallbackHandler(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "JSON 디코딩 실패", "detail": err.Error()})
 		return
 	}
+	if gu.Id == "" {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "사용자 정보에 ID가 없습니다"})
+		return
+	}
 
-	_, err = db.DB.Exec(`
-        INSERT INTO google_user (id, email, name)
-        VALUES ($1, $2, $3)
-        ON CONFLICT (id) DO NOTHING
-    `, gu.Id, gu.Email, gu.Name)
-
+	accountID, nickname, err := linkGoogleUser(gu, pending.linkAccountID)
+	if errors.Is(err, ErrGoogleAccountLinked) {
+		c.JSON(http.StatusConflict, gin.H{"error": "계정 연결 실패", "detail": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "DB 저장 실패", "detail": err.Error()})
 		return
 	}
 
+	sessionToken, err := IssueSessionToken(accountID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "세션 토큰 발급 실패", "detail": err.Error()})
+		return
+	}
+
+	log.Printf("Google 로그인 성공: Google ID=%s, 계정 ID=%s", gu.Id, accountID)
+
+	// 로그인 성공 주소가 있으면 프래그먼트로 세션 토큰 전달 (서버 로그와 Referer에 남지 않음)
+	if googleLoginSuccess != "" {
+		fragment := url.Values{
+			"sessionToken": {sessionToken},
+			"accountId":    {accountID},
+			"nickname":     {nickname},
+		}
+		c.Redirect(http.StatusFound, googleLoginSuccess+"#"+fragment.Encode())
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"message": "로그인 성공",
-		"user":    gu,
+		"message":      "로그인 성공",
+		"user":         gu,
+		"accountId":    accountID,
+		"nickname":     nickname,
+		"sessionToken": sessionToken,
 	})
 }
+
+// Google 계정을 게임 계정(Users)에 연결하고 계정 아이디와 닉네임 반환
+// linkAccountID가 있으면 그 계정에 연결하고, 없으면 기존 연결을 쓰거나 새 계정을 만듦
+func linkGoogleUser(gu GoogleUser, linkAccountID string) (string, string, error) {
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return "", "", err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+        INSERT INTO google_user (id, email, name)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (id) DO UPDATE SET email = EXCLUDED.email, name = EXCLUDED.name
+    `, gu.Id, gu.Email, gu.Name)
+	if err != nil {
+		return "", "", err
+	}
+
+	var linkedID sql.NullString
+	if err := tx.QueryRow("SELECT account_id FROM google_user WHERE id = $1 FOR UPDATE", gu.Id).Scan(&linkedID); err != nil {
+		return "", "", err
+	}
+
+	accountID := linkedID.String
+	switch {
+	case linkAccountID != "" && linkedID.Valid && linkedID.String != linkAccountID:
+		return "", "", ErrGoogleAccountLinked
+	case linkAccountID != "":
+		accountID = linkAccountID
+	case !linkedID.Valid:
+		accountID, err = createGoogleAccount(tx, gu)
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	if !linkedID.Valid || linkedID.String != accountID {
+		if _, err := tx.Exec("UPDATE google_user SET account_id = $1 WHERE id = $2", accountID, gu.Id); err != nil {
+			return "", "", err
+		}
+	}
+
+	var nickname string
+	if err := tx.QueryRow("SELECT nickname FROM Users WHERE id = $1", accountID).Scan(&nickname); err != nil {
+		return "", "", fmt.Errorf("연결된 계정 조회 오류: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", "", err
+	}
+	return accountID, nickname, nil
+}
+
+// Google 계정용 게임 계정 생성 (비밀번호가 없어 아이디/비밀번호 로그인은 불가)
+// 닉네임이 이미 쓰이고 있으면 뒤에 숫자를 붙여 다른 계정과 겹치지 않게 함
+func createGoogleAccount(tx *sql.Tx, gu GoogleUser) (string, error) {
+	baseNickname := truncateNickname(gu.Name, maxNicknameLength)
+	if baseNickname == "" {
+		baseNickname = "Player"
+	}
+
+	// 아이디는 계정 생성 규칙(10자 이하)에 맞춰 "g_" + 임의 8자
+	for attempt := 0; attempt < 10; attempt++ {
+		accountID, err := randomAccountID()
+		if err != nil {
+			return "", err
+		}
+		nickname, err := nicknameCandidate(baseNickname, attempt)
+		if err != nil {
+			return "", err
+		}
+
+		result, err := tx.Exec(`
+            INSERT INTO Users (id, password, nickname)
+            SELECT $1, '', $2
+            WHERE NOT EXISTS (SELECT 1 FROM Users WHERE nickname = $2)
+            ON CONFLICT (id) DO NOTHING
+        `, accountID, nickname)
+		if err != nil {
+			return "", err
+		}
+		if inserted, _ := result.RowsAffected(); inserted == 1 {
+			return accountID, nil
+		}
+	}
+	return "", errors.New("계정 아이디 또는 닉네임 생성 실패")
+}
+
+// 닉네임 후보 (첫 시도는 그대로, 이후에는 닉네임 길이 규칙 안에서 임의 숫자 4자리를 붙임)
+func nicknameCandidate(base string, attempt int) (string, error) {
+	if attempt == 0 {
+		return base, nil
+	}
+
+	buf := make([]byte, 4)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	for i := range buf {
+		buf[i] = '0' + buf[i]%10
+	}
+	return truncateNickname(base, maxNicknameLength-len(buf)) + string(buf), nil
+}
+
+// Google 계정용 아이디 생성
+func randomAccountID() (string, error) {
+	const charset = "abcdefghijklmnopqrstuvwxyz0123456789"
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	for i := range buf {
+		buf[i] = charset[int(buf[i])%len(charset)]
+	}
+	return "g_" + string(buf), nil
+}
+
+// 닉네임 최대 글자 수 (계정 생성 요청의 nickname 검증 규칙과 같음)
+const maxNicknameLength = 10
+
+// 닉네임을 최대 글자 수에 맞게 글자 단위로 자르기
+func truncateNickname(name string, limit int) string {
+	for utf8.RuneCountInString(name) > limit {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	return name
+}
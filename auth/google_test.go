@@ -0,0 +1,401 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+
+	"main/config"
+	"main/db"
+)
+
+// 테스트용 가짜 OAuth 서버 (토큰 교환 요청을 기록하고 정해진 사용자 정보를 돌려줌)
+type fakeProvider struct {
+	server *httptest.Server
+
+	mu           sync.Mutex
+	user         GoogleUser
+	tokenRequest url.Values
+}
+
+func newFakeProvider(t *testing.T) *fakeProvider {
+	t.Helper()
+
+	p := &fakeProvider{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		p.mu.Lock()
+		p.tokenRequest = r.PostForm
+		p.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "fake-access-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer fake-access-token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		p.mu.Lock()
+		user := p.user
+		p.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(user)
+	})
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+
+	SetupGoogleOAuth(config.OAuthConfig{
+		GoogleClientID:     "test-client",
+		GoogleClientSecret: "test-secret",
+		GoogleRedirectURL:  "http://localhost/google/oauth2",
+		GoogleAuthURL:      p.server.URL + "/auth",
+		GoogleTokenURL:     p.server.URL + "/token",
+		GoogleUserInfoURL:  p.server.URL + "/userinfo",
+	})
+	return p
+}
+
+func (p *fakeProvider) setUser(user GoogleUser) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.user = user
+}
+
+func (p *fakeProvider) lastTokenRequest() url.Values {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.tokenRequest
+}
+
+func newOAuthRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/google/auth/login", GoogleLoginHandler)
+	r.GET("/google/oauth2", GoogleCallbackHandler)
+	return r
+}
+
+// 로그인 시작 요청 후 가짜 서버로 이동하는 주소와 state 쿠키 반환
+func startLogin(t *testing.T, r *gin.Engine, sessionToken string) (*url.URL, *http.Cookie) {
+	t.Helper()
+
+	target := "/google/auth/login"
+	if sessionToken != "" {
+		target += "?token=" + url.QueryEscape(sessionToken)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+	if w.Code != http.StatusTemporaryRedirect {
+		t.Fatalf("로그인 시작 응답 코드 = %d, 본문 = %s", w.Code, w.Body.String())
+	}
+
+	location, err := url.Parse(w.Header().Get("Location"))
+	if err != nil {
+		t.Fatalf("이동 주소 파싱 실패: %v", err)
+	}
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Name == oauthStateCookie {
+			return location, cookie
+		}
+	}
+	t.Fatal("state 쿠키가 없습니다")
+	return nil, nil
+}
+
+// 콜백 요청 (cookie가 nil이면 쿠키 없이 요청)
+func callback(r *gin.Engine, state string, cookie *http.Cookie) *httptest.ResponseRecorder {
+	query := url.Values{"state": {state}, "code": {"fake-code"}}
+	req := httptest.NewRequest(http.MethodGet, "/google/oauth2?"+query.Encode(), nil)
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestGoogleCallbackRejectsStateMismatch(t *testing.T) {
+	newFakeProvider(t)
+	r := newOAuthRouter()
+
+	location, cookie := startLogin(t, r, "")
+	state := location.Query().Get("state")
+	if state == "" || state != cookie.Value {
+		t.Fatalf("이동 주소의 state(%q)와 쿠키(%q)가 다릅니다", state, cookie.Value)
+	}
+
+	// 다른 브라우저의 쿠키
+	other := &http.Cookie{Name: oauthStateCookie, Value: "other-state"}
+	if w := callback(r, state, other); w.Code != http.StatusBadRequest {
+		t.Errorf("쿠키가 다른 콜백 응답 코드 = %d, 기대값 %d", w.Code, http.StatusBadRequest)
+	}
+
+	// 쿠키 없음
+	if w := callback(r, state, nil); w.Code != http.StatusBadRequest {
+		t.Errorf("쿠키가 없는 콜백 응답 코드 = %d, 기대값 %d", w.Code, http.StatusBadRequest)
+	}
+
+	// 발급한 적 없는 state (쿠키와는 일치)
+	forged := &http.Cookie{Name: oauthStateCookie, Value: "forged-state"}
+	if w := callback(r, "forged-state", forged); w.Code != http.StatusBadRequest {
+		t.Errorf("발급하지 않은 state 콜백 응답 코드 = %d, 기대값 %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestGoogleCallbackSendsPKCEVerifier(t *testing.T) {
+	provider := newFakeProvider(t)
+	// ID가 없는 사용자 정보로 DB 저장 전에 멈추게 함
+	provider.setUser(GoogleUser{})
+	r := newOAuthRouter()
+
+	location, cookie := startLogin(t, r, "")
+	if method := location.Query().Get("code_challenge_method"); method != "S256" {
+		t.Fatalf("code_challenge_method = %q, 기대값 S256", method)
+	}
+	challenge := location.Query().Get("code_challenge")
+
+	w := callback(r, cookie.Value, cookie)
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("ID 없는 사용자 정보 콜백 응답 코드 = %d, 본문 = %s", w.Code, w.Body.String())
+	}
+
+	form := provider.lastTokenRequest()
+	if form == nil {
+		t.Fatal("토큰 교환 요청이 없습니다")
+	}
+	if code := form.Get("code"); code != "fake-code" {
+		t.Errorf("토큰 교환 code = %q, 기대값 fake-code", code)
+	}
+	verifier := form.Get("code_verifier")
+	if verifier == "" {
+		t.Fatal("토큰 교환 요청에 code_verifier가 없습니다")
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	if got := base64.RawURLEncoding.EncodeToString(sum[:]); got != challenge {
+		t.Errorf("code_verifier의 S256 = %q, code_challenge = %q", got, challenge)
+	}
+
+	// 한 번 쓴 state는 다시 쓸 수 없음
+	if w := callback(r, cookie.Value, cookie); w.Code != http.StatusBadRequest {
+		t.Errorf("재사용한 state 콜백 응답 코드 = %d, 기대값 %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestNicknameCandidate(t *testing.T) {
+	base := truncateNickname("아주긴구글사용자이름입니다", maxNicknameLength)
+	if base != "아주긴구글사용자이름" {
+		t.Fatalf("잘린 닉네임 = %q", base)
+	}
+
+	if first, _ := nicknameCandidate(base, 0); first != base {
+		t.Errorf("첫 후보 = %q, 기대값 %q", first, base)
+	}
+	for attempt := 1; attempt < 5; attempt++ {
+		candidate, err := nicknameCandidate(base, attempt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n := utf8.RuneCountInString(candidate); n > maxNicknameLength {
+			t.Errorf("후보 %q는 %d자, 최대 %d자", candidate, n, maxNicknameLength)
+		}
+		if !strings.HasPrefix(candidate, "아주긴구글사") {
+			t.Errorf("후보 %q가 원래 닉네임으로 시작하지 않음", candidate)
+		}
+	}
+}
+
+// 계정 연결 테스트용 DB 연결 (HALLIGALLI_TEST_DB_DSN이 없으면 건너뜀)
+func setupTestDB(t *testing.T) {
+	t.Helper()
+
+	dsn := os.Getenv("HALLIGALLI_TEST_DB_DSN")
+	if dsn == "" {
+		t.Skip("HALLIGALLI_TEST_DB_DSN이 설정되지 않아 DB가 필요한 테스트를 건너뜀")
+	}
+	if db.DB == nil {
+		db.Init(dsn)
+	}
+	SetupSessions("test-session-secret-0123456789abcdef", time.Hour)
+}
+
+// 테스트가 끝나면 만든 Google 계정 연결과 게임 계정 삭제
+func cleanupGoogleUser(t *testing.T, googleID string, accountIDs ...string) {
+	t.Cleanup(func() {
+		db.DB.Exec("DELETE FROM google_user WHERE id = $1", googleID)
+		for _, accountID := range accountIDs {
+			db.DB.Exec("DELETE FROM google_user WHERE account_id = $1", accountID)
+			db.DB.Exec("DELETE FROM Users WHERE id = $1", accountID)
+		}
+	})
+}
+
+// 테스트용 게임 계정 생성
+func createTestAccount(t *testing.T) string {
+	t.Helper()
+
+	accountID, err := randomAccountID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.DB.Exec("INSERT INTO Users (id, password, nickname) VALUES ($1, 'x', 'tester')", accountID); err != nil {
+		t.Fatalf("테스트 계정 생성 실패: %v", err)
+	}
+	return accountID
+}
+
+// 로그인 성공 응답 본문
+type loginResponse struct {
+	AccountID    string `json:"accountId"`
+	Nickname     string `json:"nickname"`
+	SessionToken string `json:"sessionToken"`
+}
+
+func completeLogin(t *testing.T, r *gin.Engine, sessionToken string) *httptest.ResponseRecorder {
+	t.Helper()
+	_, cookie := startLogin(t, r, sessionToken)
+	return callback(r, cookie.Value, cookie)
+}
+
+func linkedAccount(t *testing.T, googleID string) string {
+	t.Helper()
+	var accountID string
+	if err := db.DB.QueryRow("SELECT account_id FROM google_user WHERE id = $1", googleID).Scan(&accountID); err != nil {
+		t.Fatalf("google_user 조회 실패: %v", err)
+	}
+	return accountID
+}
+
+func TestGoogleLoginIssuesSessionToken(t *testing.T) {
+	setupTestDB(t)
+	provider := newFakeProvider(t)
+	r := newOAuthRouter()
+
+	googleID := "test-" + uniqueTestSuffix(t)
+	provider.setUser(GoogleUser{Id: googleID, Email: "new@example.com", Name: "새로운플레이어"})
+
+	w := completeLogin(t, r, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("콜백 응답 코드 = %d, 본문 = %s", w.Code, w.Body.String())
+	}
+	var resp loginResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	cleanupGoogleUser(t, googleID, resp.AccountID)
+
+	if resp.Nickname != "새로운플레이어" {
+		t.Errorf("닉네임 = %q", resp.Nickname)
+	}
+	claims, err := VerifySessionToken(resp.SessionToken)
+	if err != nil {
+		t.Fatalf("발급된 세션 토큰 검증 실패: %v", err)
+	}
+	if claims.AccountID != resp.AccountID {
+		t.Errorf("세션 토큰 계정 = %q, 응답 계정 = %q", claims.AccountID, resp.AccountID)
+	}
+	if linked := linkedAccount(t, googleID); linked != resp.AccountID {
+		t.Errorf("google_user 연결 계정 = %q, 기대값 %q", linked, resp.AccountID)
+	}
+
+	// 같은 Google 계정으로 다시 로그인하면 같은 게임 계정
+	w = completeLogin(t, r, "")
+	var again loginResponse
+	json.Unmarshal(w.Body.Bytes(), &again)
+	if w.Code != http.StatusOK || again.AccountID != resp.AccountID {
+		t.Errorf("재로그인 응답 코드 = %d, 계정 = %q, 기대값 %q", w.Code, again.AccountID, resp.AccountID)
+	}
+}
+
+func TestGoogleLoginLinksExistingAccount(t *testing.T) {
+	setupTestDB(t)
+	provider := newFakeProvider(t)
+	r := newOAuthRouter()
+
+	accountID := createTestAccount(t)
+	googleID := "test-" + uniqueTestSuffix(t)
+	cleanupGoogleUser(t, googleID, accountID)
+	provider.setUser(GoogleUser{Id: googleID, Email: "link@example.com", Name: "Linker"})
+
+	sessionToken, err := IssueSessionToken(accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := completeLogin(t, r, sessionToken)
+	if w.Code != http.StatusOK {
+		t.Fatalf("계정 연결 콜백 응답 코드 = %d, 본문 = %s", w.Code, w.Body.String())
+	}
+	var resp loginResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.AccountID != accountID || resp.Nickname != "tester" {
+		t.Errorf("응답 계정 = %q (%q), 기대값 %q (tester)", resp.AccountID, resp.Nickname, accountID)
+	}
+	if linked := linkedAccount(t, googleID); linked != accountID {
+		t.Errorf("google_user 연결 계정 = %q, 기대값 %q", linked, accountID)
+	}
+}
+
+func TestGoogleLoginLinkConflict(t *testing.T) {
+	setupTestDB(t)
+	provider := newFakeProvider(t)
+	r := newOAuthRouter()
+
+	owner := createTestAccount(t)
+	other := createTestAccount(t)
+	googleID := "test-" + uniqueTestSuffix(t)
+	cleanupGoogleUser(t, googleID, owner, other)
+	provider.setUser(GoogleUser{Id: googleID, Email: "owner@example.com", Name: "Owner"})
+
+	ownerToken, err := IssueSessionToken(owner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w := completeLogin(t, r, ownerToken); w.Code != http.StatusOK {
+		t.Fatalf("첫 연결 콜백 응답 코드 = %d, 본문 = %s", w.Code, w.Body.String())
+	}
+
+	// 이미 owner에 연결된 Google 계정을 other에 연결하려 하면 거절
+	otherToken, err := IssueSessionToken(other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w := completeLogin(t, r, otherToken); w.Code != http.StatusConflict {
+		t.Errorf("다른 계정 연결 콜백 응답 코드 = %d, 기대값 %d", w.Code, http.StatusConflict)
+	}
+	if linked := linkedAccount(t, googleID); linked != owner {
+		t.Errorf("google_user 연결 계정 = %q, 기대값 %q", linked, owner)
+	}
+}
+
+// 테스트마다 겹치지 않는 Google ID 접미사
+func uniqueTestSuffix(t *testing.T) string {
+	t.Helper()
+	suffix, err := randomAccountID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return suffix
+}
@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"sync"
+	"time"
+)
+
+// OAuth 로그인 요청 유효시간 (로그인 시작부터 콜백까지)
+const oauthStateTTL = 10 * time.Minute
+
+// 진행 중인 OAuth 로그인 요청 (state 값으로 찾음)
+type oauthLoginState struct {
+	verifier      string    // PKCE code_verifier
+	linkAccountID string    // 연결할 기존 계정 아이디 (새 계정을 만들거나 기존 연결을 쓰면 빈 문자열)
+	expiresAt     time.Time // 만료 시각
+}
+
+// 진행 중인 OAuth 로그인 요청 저장소 (state는 한 번만 사용 가능)
+type oauthStateStore struct {
+	mu     sync.Mutex
+	states map[string]*oauthLoginState
+}
+
+func newOAuthStateStore() *oauthStateStore {
+	return &oauthStateStore{
+		states: make(map[string]*oauthLoginState),
+	}
+}
+
+// 새 state 발급 (만료된 요청은 함께 정리)
+func (s *oauthStateStore) issue(verifier, linkAccountID string) (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	state := base64.RawURLEncoding.EncodeToString(buf)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for key, pending := range s.states {
+		if now.After(pending.expiresAt) {
+			delete(s.states, key)
+		}
+	}
+	s.states[state] = &oauthLoginState{
+		verifier:      verifier,
+		linkAccountID: linkAccountID,
+		expiresAt:     now.Add(oauthStateTTL),
+	}
+	return state, nil
+}
+
+// state에 해당하는 요청을 꺼내고 삭제 (없거나 만료되었으면 nil)
+func (s *oauthStateStore) claim(state string) *oauthLoginState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pending, exists := s.states[state]
+	if !exists {
+		return nil
+	}
+	delete(s.states, state)
+
+	if time.Now().After(pending.expiresAt) {
+		return nil
+	}
+	return pending
+}
@@ -0,0 +1,126 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"main/db"
+)
+
+// 세션 토큰 에러
+var (
+	ErrInvalidSessionToken = errors.New("유효하지 않은 세션 토큰입니다")
+	ErrSessionExpired      = errors.New("만료된 세션 토큰입니다")
+	ErrSessionRevoked      = errors.New("로그아웃된 세션 토큰입니다")
+)
+
+// 세션 토큰에 담기는 정보
+type SessionClaims struct {
+	SessionID string `json:"sid"` // 세션 ID (로그아웃 시 폐기 목록에 기록)
+	AccountID string `json:"sub"` // 로그인한 계정 아이디
+	ExpiresAt int64  `json:"exp"` // 만료 시각 (Unix 초)
+}
+
+var (
+	sessionSecret []byte
+	sessionTTL    time.Duration
+)
+
+// 세션 토큰 서명 키와 유효기간 설정
+func SetupSessions(secret string, ttl time.Duration) {
+	sessionSecret = []byte(secret)
+	sessionTTL = ttl
+}
+
+// 계정에 대한 서명된 세션 토큰 발급 (형식: base64(정보).base64(HMAC-SHA256))
+func IssueSessionToken(accountID string) (string, error) {
+	sessionID := make([]byte, 16)
+	if _, err := rand.Read(sessionID); err != nil {
+		return "", fmt.Errorf("세션 ID 생성 실패: %w", err)
+	}
+
+	claims := SessionClaims{
+		SessionID: hex.EncodeToString(sessionID),
+		AccountID: accountID,
+		ExpiresAt: time.Now().Add(sessionTTL).Unix(),
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + signSession(encoded), nil
+}
+
+// 세션 토큰의 서명, 만료, 폐기 여부 검증
+func VerifySessionToken(token string) (*SessionClaims, error) {
+	claims, err := parseSessionToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if time.Now().Unix() >= claims.ExpiresAt {
+		return nil, ErrSessionExpired
+	}
+
+	var revoked bool
+	err = db.DB.QueryRow("SELECT EXISTS (SELECT 1 FROM revoked_sessions WHERE session_id = $1)", claims.SessionID).Scan(&revoked)
+	if err != nil {
+		return nil, fmt.Errorf("세션 폐기 여부 조회 오류: %w", err)
+	}
+	if revoked {
+		return nil, ErrSessionRevoked
+	}
+	return claims, nil
+}
+
+// 세션 토큰 폐기 (로그아웃, 만료 시각까지 폐기 목록에 보관)
+func RevokeSessionToken(token string) error {
+	claims, err := parseSessionToken(token)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.DB.Exec(`
+        INSERT INTO revoked_sessions (session_id, account_id, expires_at)
+        VALUES ($1, $2, to_timestamp($3))
+        ON CONFLICT (session_id) DO NOTHING
+    `, claims.SessionID, claims.AccountID, claims.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("세션 폐기 저장 오류: %w", err)
+	}
+	return nil
+}
+
+// 세션 토큰 서명 확인 후 정보 추출 (만료/폐기 여부는 확인하지 않음)
+func parseSessionToken(token string) (*SessionClaims, error) {
+	encoded, signature, found := strings.Cut(token, ".")
+	if !found || !hmac.Equal([]byte(signature), []byte(signSession(encoded))) {
+		return nil, ErrInvalidSessionToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidSessionToken
+	}
+	var claims SessionClaims
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.AccountID == "" {
+		return nil, ErrInvalidSessionToken
+	}
+	return &claims, nil
+}
+
+// 세션 토큰 서명 생성
+func signSession(encoded string) string {
+	mac := hmac.New(sha256.New, sessionSecret)
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
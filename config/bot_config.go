@@ -0,0 +1,109 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+// 봇 난이도
+const (
+	BotDifficultyEasy   = "easy"
+	BotDifficultyNormal = "normal"
+	BotDifficultyHard   = "hard"
+
+	DefaultBotDifficulty = BotDifficultyNormal // 난이도를 지정하지 않았을 때 사용
+)
+
+// 봇 난이도별 행동 설정
+type BotProfile struct {
+	ReactionMeanMs   int     `json:"reactionMeanMs" yaml:"reactionMeanMs"`     // 종을 칠 타이밍에 반응하는 평균 시간 (밀리초)
+	ReactionStdDevMs int     `json:"reactionStdDevMs" yaml:"reactionStdDevMs"` // 반응 시간 표준편차 (밀리초)
+	MinReactionMs    int     `json:"minReactionMs" yaml:"minReactionMs"`       // 최소 반응 시간 (밀리초)
+	MissRate         float64 `json:"missRate" yaml:"missRate"`                 // 종을 칠 타이밍을 놓칠 확률 (0-1)
+	FalseRingRate    float64 `json:"falseRingRate" yaml:"falseRingRate"`       // 종을 칠 타이밍이 아닌데 잘못 칠 확률 (0-1)
+}
+
+// 설정 파일의 봇 설정 (지정하지 않은 항목은 nil)
+type botProfileOverride struct {
+	ReactionMeanMs   *int     `json:"reactionMeanMs" yaml:"reactionMeanMs"`
+	ReactionStdDevMs *int     `json:"reactionStdDevMs" yaml:"reactionStdDevMs"`
+	MinReactionMs    *int     `json:"minReactionMs" yaml:"minReactionMs"`
+	MissRate         *float64 `json:"missRate" yaml:"missRate"`
+	FalseRingRate    *float64 `json:"falseRingRate" yaml:"falseRingRate"`
+}
+
+// 설정 파일에서 지정한 항목만 덮어쓴 봇 설정
+func (p BotProfile) merge(o botProfileOverride) BotProfile {
+	if o.ReactionMeanMs != nil {
+		p.ReactionMeanMs = *o.ReactionMeanMs
+	}
+	if o.ReactionStdDevMs != nil {
+		p.ReactionStdDevMs = *o.ReactionStdDevMs
+	}
+	if o.MinReactionMs != nil {
+		p.MinReactionMs = *o.MinReactionMs
+	}
+	if o.MissRate != nil {
+		p.MissRate = *o.MissRate
+	}
+	if o.FalseRingRate != nil {
+		p.FalseRingRate = *o.FalseRingRate
+	}
+	return p
+}
+
+// 난이도별 봇 기본 설정 (설정 파일의 bots 항목으로 덮어씀)
+func DefaultBotProfiles() map[string]BotProfile {
+	return map[string]BotProfile{
+		BotDifficultyEasy: {
+			ReactionMeanMs:   1400,
+			ReactionStdDevMs: 400,
+			MinReactionMs:    600,
+			MissRate:         0.35,
+			FalseRingRate:    0.08,
+		},
+		BotDifficultyNormal: {
+			ReactionMeanMs:   900,
+			ReactionStdDevMs: 250,
+			MinReactionMs:    400,
+			MissRate:         0.15,
+			FalseRingRate:    0.04,
+		},
+		BotDifficultyHard: {
+			ReactionMeanMs:   550,
+			ReactionStdDevMs: 120,
+			MinReactionMs:    250,
+			MissRate:         0.05,
+			FalseRingRate:    0.01,
+		},
+	}
+}
+
+// 봇 난이도인지 확인
+func IsValidBotDifficulty(difficulty string) bool {
+	switch difficulty {
+	case BotDifficultyEasy, BotDifficultyNormal, BotDifficultyHard:
+		return true
+	}
+	return false
+}
+
+// 봇 설정 검증
+func (p BotProfile) Validate() error {
+	if p.ReactionMeanMs < 1 {
+		return errors.New("평균 반응 시간(reactionMeanMs)은 1밀리초 이상이어야 합니다")
+	}
+	if p.ReactionStdDevMs < 0 {
+		return errors.New("반응 시간 표준편차(reactionStdDevMs)는 0 이상이어야 합니다")
+	}
+	if p.MinReactionMs < 0 || p.MinReactionMs > p.ReactionMeanMs {
+		return errors.New("최소 반응 시간(minReactionMs)은 0 이상, 평균 반응 시간 이하여야 합니다")
+	}
+	if p.MissRate < 0 || p.MissRate > 1 {
+		return fmt.Errorf("놓칠 확률(missRate)은 0-1 사이여야 합니다: %v", p.MissRate)
+	}
+	if p.FalseRingRate < 0 || p.FalseRingRate > 1 {
+		return fmt.Errorf("잘못 칠 확률(falseRingRate)은 0-1 사이여야 합니다: %v", p.FalseRingRate)
+	}
+	return nil
+}
@@ -1,42 +1,138 @@
 package config
 
+import (
+	"fmt"
+	"sync"
+)
+
 // 게임 설정 상수들
 const (
 	// 방 설정
-	MaxPlayers = 4 // 방에 들어갈 수 있는 최대 플레이어 수
+	MaxPlayers    = 4  // 방에 들어갈 수 있는 최대 플레이어 수
+	MaxSpectators = 20 // 방마다 관전할 수 있는 최대 관전자 수
 
 	// 벨 누르기 설정s
 	BellRingingFruitCount = 5 // 종을 올바르게 치기 위한 과일 개수
+	// 첫 벨 누름 이후 다른 플레이어의 누름을 더 모으는 시간 (밀리초, 지연 보정 최대값보다 작으면 보정이 의미 없음)
+	BellAdjudicationWindowMs = 150
+	// 벨 누른 시각에서 빼 주는 편도 지연 시간(왕복 지연 시간의 절반)의 최대값 (밀리초, 지연을 부풀려 이득을 보지 못하도록 제한)
+	MaxBellLatencyCompensationMs = 150
 
 	// 카드 공개 설정
 	CardOpenInterval = 2 // 카드 공개 간격 (초)
 
 	// 게임 시작 설정
-	StartingCards = 10 // 게임 시작 시 각 플레이어가 받는 카드 수
+	StartingCards  = 10 // 게임 시작 시 각 플레이어가 받는 카드 수
+	MinPlayers     = 2  // 게임을 시작하기 위한 최소 플레이어 수
+	StartCountdown = 10 // 카운트다운 방식에서 최소 인원이 모인 뒤 게임 시작까지의 시간 (초)
 
 	// 게임 제한시간 설정
 	GameTimeLimit = 120 // 게임 제한시간 (초)
 
 	// 감정표현 설정
 	EmotionCooldown = 2 // 감정표현 사이 제한시간 (초)
+
+	// 재접속 설정
+	ReconnectGracePeriod = 30 // 게임 중 연결이 끊긴 플레이어가 좌석을 되찾을 수 있는 시간 (초)
+)
+
+// 게임 시작 방식 (방 생성 시 선택)
+const (
+	StartPolicyFullRoom  = "fullRoom"  // 최대 인원이 모이면 자동 시작
+	StartPolicyHost      = "host"      // 방장이 시작 요청
+	StartPolicyAllReady  = "allReady"  // 최소 인원 이상이 모두 준비하면 시작
+	StartPolicyCountdown = "countdown" // 최소 인원이 모이면 카운트다운 후 시작
+
+	DefaultStartPolicy = StartPolicyFullRoom // 시작 방식을 지정하지 않았을 때 사용
+)
+
+// 지원하는 게임 시작 방식인지 확인
+func IsValidStartPolicy(policy string) bool {
+	switch policy {
+	case StartPolicyFullRoom, StartPolicyHost, StartPolicyAllReady, StartPolicyCountdown:
+		return true
+	}
+	return false
+}
+
+// 방마다 다르게 설정할 수 있는 게임 설정 범위
+const (
+	MaxPlayersLimit     = 8   // 방 최대 인원의 상한
+	MaxCardOpenInterval = 10  // 카드 공개 간격의 상한 (초)
+	MinGameTimeLimit    = 30  // 게임 제한시간의 하한 (초)
+	MaxGameTimeLimit    = 600 // 게임 제한시간의 상한 (초)
+	MaxBellRingingFruit = 10  // 종을 치기 위한 과일 개수의 상한
+	MaxEmotionCooldown  = 10  // 감정표현 제한시간의 상한 (초)
 )
 
-// 게임 설정 구조체 (향후 확장성을 위해)
+// 게임 설정 구조체 (방마다 하나씩 가지며 방 생성 시 검증)
 type GameConfig struct {
-	MaxPlayers            int `json:"maxPlayers"`
-	BellRingingFruitCount int `json:"bellRingingFruitCount"`
-	CardOpenInterval      int `json:"cardOpenInterval"`
-	StartingCards         int `json:"startingCards"`
-	GameTimeLimit         int `json:"gameTimeLimit"`
+	MinPlayers            int `json:"minPlayers" yaml:"minPlayers"`                       // 게임을 시작하기 위한 최소 플레이어 수
+	MaxPlayers            int `json:"maxPlayers" yaml:"maxPlayers"`                       // 방에 들어갈 수 있는 최대 플레이어 수
+	BellRingingFruitCount int `json:"bellRingingFruitCount" yaml:"bellRingingFruitCount"` // 종을 올바르게 치기 위한 과일 개수
+	CardOpenInterval      int `json:"cardOpenInterval" yaml:"cardOpenInterval"`           // 카드 공개 간격 (초)
+	StartingCards         int `json:"startingCards" yaml:"startingCards"`                 // 게임 시작 시 각 플레이어가 받는 카드 수
+	GameTimeLimit         int `json:"gameTimeLimit" yaml:"gameTimeLimit"`                 // 게임 제한시간 (초)
+	EmotionCooldown       int `json:"emotionCooldown" yaml:"emotionCooldown"`             // 감정표현 사이 제한시간 (초)
 }
 
-// 기본 게임 설정 반환
-func GetDefaultConfig() *GameConfig {
-	return &GameConfig{
+// 새로 만드는 방에 적용되는 기본 게임 설정 (설정 파일에서 불러오며 SIGHUP으로 다시 불러올 수 있음)
+var (
+	defaultConfigMu sync.RWMutex
+	defaultConfig   = constantDefaultConfig()
+)
+
+// 상수로 정의된 기본 게임 설정
+func constantDefaultConfig() GameConfig {
+	return GameConfig{
+		MinPlayers:            MinPlayers,
 		MaxPlayers:            MaxPlayers,
 		BellRingingFruitCount: BellRingingFruitCount,
 		CardOpenInterval:      CardOpenInterval,
 		StartingCards:         StartingCards,
 		GameTimeLimit:         GameTimeLimit,
+		EmotionCooldown:       EmotionCooldown,
+	}
+}
+
+// 기본 게임 설정 반환 (호출할 때마다 새 복사본)
+func GetDefaultConfig() *GameConfig {
+	defaultConfigMu.RLock()
+	defer defaultConfigMu.RUnlock()
+
+	cfg := defaultConfig
+	return &cfg
+}
+
+// 기본 게임 설정 교체 (이미 만들어진 방은 자신의 설정을 그대로 사용)
+func SetDefaultConfig(cfg GameConfig) {
+	defaultConfigMu.Lock()
+	defer defaultConfigMu.Unlock()
+	defaultConfig = cfg
+}
+
+// 게임 설정 값이 허용 범위 안에 있는지 검증
+func (c *GameConfig) Validate() error {
+	if c.MinPlayers < MinPlayers || c.MinPlayers > c.MaxPlayers {
+		return fmt.Errorf("최소 인원은 %d명 이상 최대 인원 이하여야 합니다", MinPlayers)
+	}
+	if c.MaxPlayers > MaxPlayersLimit {
+		return fmt.Errorf("최대 인원은 %d명을 넘을 수 없습니다", MaxPlayersLimit)
+	}
+	if c.BellRingingFruitCount < 1 || c.BellRingingFruitCount > MaxBellRingingFruit {
+		return fmt.Errorf("종을 치기 위한 과일 개수는 1개 이상 %d개 이하여야 합니다", MaxBellRingingFruit)
+	}
+	if c.CardOpenInterval < 1 || c.CardOpenInterval > MaxCardOpenInterval {
+		return fmt.Errorf("카드 공개 간격은 1초 이상 %d초 이하여야 합니다", MaxCardOpenInterval)
+	}
+	if c.StartingCards < 1 {
+		return fmt.Errorf("시작 카드 수는 1장 이상이어야 합니다")
+	}
+	if c.GameTimeLimit < MinGameTimeLimit || c.GameTimeLimit > MaxGameTimeLimit {
+		return fmt.Errorf("게임 제한시간은 %d초 이상 %d초 이하여야 합니다", MinGameTimeLimit, MaxGameTimeLimit)
+	}
+	if c.EmotionCooldown < 0 || c.EmotionCooldown > MaxEmotionCooldown {
+		return fmt.Errorf("감정표현 제한시간은 0초 이상 %d초 이하여야 합니다", MaxEmotionCooldown)
 	}
+	return nil
 }
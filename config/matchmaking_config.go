@@ -0,0 +1,17 @@
+package config
+
+// 레이팅 설정 (여러 명이 겨룬 게임을 모든 두 명 쌍의 Elo 대결로 계산)
+const (
+	DefaultRating = 1500 // 처음 계정을 만들었을 때의 레이팅 (Users.rating 기본값과 같아야 함)
+	RatingK       = 32   // 한 게임에서 레이팅이 움직이는 최대 폭
+)
+
+// 매칭 대기열 설정
+const (
+	MatchmakingInterval           = 1    // 매칭 시도 간격 (초)
+	MatchmakingStatusInterval     = 5    // 대기 중인 클라이언트에게 대기 상태를 보내는 간격 (초)
+	MatchmakingBaseTolerance      = 100  // 처음 허용하는 레이팅 차이
+	MatchmakingTolerancePerSecond = 10   // 대기 1초마다 늘어나는 허용 레이팅 차이
+	MatchmakingMaxTolerance       = 1000 // 허용 레이팅 차이 최대값
+	MatchmakingPartialAfter       = 60   // 이 시간(초) 이상 기다리면 최대 인원이 모이지 않아도 최소 인원으로 매칭
+)
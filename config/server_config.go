@@ -0,0 +1,339 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 서버 설정 기본값
+const (
+	DefaultConfigPath = "config.yaml" // 설정 파일 경로를 지정하지 않았을 때 사용
+	DefaultListenAddr = ":8081"       // 서버 주소를 지정하지 않았을 때 사용
+)
+
+// 서버 설정 구조체 (설정 파일과 환경변수에서 불러옴)
+type ServerConfig struct {
+	ListenAddr     string                `json:"listenAddr" yaml:"listenAddr"`         // 서버 주소 (예: ":8081")
+	DatabaseDSN    string                `json:"databaseDsn" yaml:"databaseDsn"`       // Postgres 접속 정보
+	AllowedOrigins []string              `json:"allowedOrigins" yaml:"allowedOrigins"` // WebSocket 연결을 허용할 Origin (비어있거나 "*"면 모두 허용)
+	OAuth          OAuthConfig           `json:"oauth" yaml:"oauth"`                   // OAuth 설정
+	Session        SessionConfig         `json:"session" yaml:"session"`               // 로그인 세션 토큰 설정
+	BannedWords    []string              `json:"bannedWords" yaml:"bannedWords"`       // 닉네임에 쓸 수 없는 금칙어 목록
+	Leaderboard    LeaderboardConfig     `json:"leaderboard" yaml:"leaderboard"`       // 순위표 설정
+	Latency        LatencyConfig         `json:"latency" yaml:"latency"`               // 지연 시간 측정 설정
+	Bots           map[string]BotProfile `json:"-" yaml:"-"`                           // 난이도별 봇 설정 (설정 파일의 bots 항목에서 지정한 항목만 기본값을 덮어씀)
+	Game           GameConfig            `json:"game" yaml:"game"`                     // 새로 만드는 방의 기본 게임 설정
+}
+
+// OAuth 설정 구조체 (GoogleClientID가 비어있으면 Google 로그인 비활성화)
+type OAuthConfig struct {
+	GoogleClientID     string `json:"googleClientId" yaml:"googleClientId"`
+	GoogleClientSecret string `json:"googleClientSecret" yaml:"googleClientSecret"`
+	GoogleRedirectURL  string `json:"googleRedirectUrl" yaml:"googleRedirectUrl"`
+	LoginSuccessURL    string `json:"loginSuccessUrl" yaml:"loginSuccessUrl"` // 로그인 성공 후 세션 토큰을 프래그먼트로 넘겨줄 주소 (비어있으면 JSON 응답)
+	// 인증 서버 주소 (비어있으면 Google 주소 사용, 로컬 가짜 인증 서버로 확인할 때 지정)
+	GoogleAuthURL     string `json:"googleAuthUrl" yaml:"googleAuthUrl"`
+	GoogleTokenURL    string `json:"googleTokenUrl" yaml:"googleTokenUrl"`
+	GoogleUserInfoURL string `json:"googleUserInfoUrl" yaml:"googleUserInfoUrl"`
+}
+
+// Google 로그인을 사용하는지 확인
+func (c OAuthConfig) GoogleEnabled() bool {
+	return c.GoogleClientID != ""
+}
+
+// 로그인 세션 토큰 설정 구조체
+type SessionConfig struct {
+	Secret   string `json:"secret" yaml:"secret"`     // 세션 토큰 서명 키 (HMAC-SHA256)
+	TTLHours int    `json:"ttlHours" yaml:"ttlHours"` // 세션 토큰 유효기간 (시간)
+}
+
+// 세션 토큰 설정 범위
+const (
+	MinSessionSecretLength = 32     // 세션 토큰 서명 키의 최소 길이
+	DefaultSessionTTLHours = 24 * 7 // 세션 토큰 유효기간 기본값 (시간)
+)
+
+// 순위표 설정 구조체
+type LeaderboardConfig struct {
+	Size           int `json:"size" yaml:"size"`                     // 순위표마다 보여줄 계정 수
+	RefreshSeconds int `json:"refreshSeconds" yaml:"refreshSeconds"` // 순위표를 다시 집계하는 간격 (초)
+	MinBellPresses int `json:"minBellPresses" yaml:"minBellPresses"` // 벨 정확도 순위표에 오르기 위한 최소 벨 누른 횟수
+}
+
+// 순위표 설정 범위
+const (
+	DefaultLeaderboardSize    = 100 // 순위표 크기 기본값 (최대값)
+	DefaultLeaderboardRefresh = 60  // 순위표 집계 간격 기본값 (초)
+	DefaultMinBellPresses     = 20  // 벨 정확도 순위표 최소 벨 누른 횟수 기본값
+	MinLeaderboardRefresh     = 10  // 순위표 집계 간격 최소값 (초)
+)
+
+// 지연 시간 측정 설정 구조체
+type LatencyConfig struct {
+	PingIntervalSeconds int `json:"pingIntervalSeconds" yaml:"pingIntervalSeconds"` // 왕복 지연 시간을 측정하는 핑 간격 (초, 방마다 지연 시간을 알리는 간격과 같음)
+	WarnMs              int `json:"warnMs" yaml:"warnMs"`                           // 클라이언트에게 경고를 보내는 왕복 지연 시간 (밀리초)
+	DropMs              int `json:"dropMs" yaml:"dropMs"`                           // 연결을 끊는 왕복 지연 시간 (밀리초, 0이면 끊지 않음)
+	DropAfter           int `json:"dropAfter" yaml:"dropAfter"`                     // 연속으로 몇 번 측정해도 dropMs를 넘으면 연결을 끊는지
+}
+
+// 지연 시간 측정 설정 범위
+const (
+	DefaultPingInterval     = 5    // 핑 간격 기본값 (초)
+	MaxPingInterval         = 50   // 핑 간격 최대값 (초, 퐁을 기다리는 60초보다 짧아야 함)
+	DefaultLatencyWarnMs    = 300  // 경고 기준 기본값 (밀리초)
+	DefaultLatencyDropMs    = 1000 // 연결 종료 기준 기본값 (밀리초)
+	DefaultLatencyDropAfter = 3    // 연결 종료까지 연속으로 넘어야 하는 측정 횟수 기본값
+)
+
+// 지연 시간 측정 기본 설정
+func DefaultLatencyConfig() LatencyConfig {
+	return LatencyConfig{
+		PingIntervalSeconds: DefaultPingInterval,
+		WarnMs:              DefaultLatencyWarnMs,
+		DropMs:              DefaultLatencyDropMs,
+		DropAfter:           DefaultLatencyDropAfter,
+	}
+}
+
+// 환경변수로 덮어쓸 수 있는 게임 설정 (환경변수 이름 -> 설정 값)
+func (c *ServerConfig) gameEnvOverrides() map[string]*int {
+	return map[string]*int{
+		"HALLIGALLI_GAME_MIN_PLAYERS":              &c.Game.MinPlayers,
+		"HALLIGALLI_GAME_MAX_PLAYERS":              &c.Game.MaxPlayers,
+		"HALLIGALLI_GAME_BELL_RINGING_FRUIT_COUNT": &c.Game.BellRingingFruitCount,
+		"HALLIGALLI_GAME_CARD_OPEN_INTERVAL":       &c.Game.CardOpenInterval,
+		"HALLIGALLI_GAME_STARTING_CARDS":           &c.Game.StartingCards,
+		"HALLIGALLI_GAME_TIME_LIMIT":               &c.Game.GameTimeLimit,
+		"HALLIGALLI_GAME_EMOTION_COOLDOWN":         &c.Game.EmotionCooldown,
+	}
+}
+
+// 설정 파일을 읽고 환경변수로 덮어쓴 뒤 검증
+// 기본 경로의 설정 파일이 없으면 기본값과 환경변수만 사용
+func Load(path string) (*ServerConfig, error) {
+	cfg := &ServerConfig{
+		ListenAddr: DefaultListenAddr,
+		OAuth: OAuthConfig{
+			GoogleRedirectURL: "http://localhost:8081/google/oauth2",
+		},
+		Session: SessionConfig{
+			TTLHours: DefaultSessionTTLHours,
+		},
+		Leaderboard: LeaderboardConfig{
+			Size:           DefaultLeaderboardSize,
+			RefreshSeconds: DefaultLeaderboardRefresh,
+			MinBellPresses: DefaultMinBellPresses,
+		},
+		Latency: DefaultLatencyConfig(),
+		Bots:    DefaultBotProfiles(),
+		Game:    constantDefaultConfig(),
+	}
+
+	if err := cfg.loadFile(path); err != nil {
+		return nil, err
+	}
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// 설정 파일 읽기 (확장자가 .json이면 JSON, 아니면 YAML)
+func (c *ServerConfig) loadFile(path string) error {
+	if path == "" {
+		path = DefaultConfigPath
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && path == DefaultConfigPath {
+		log.Printf("설정 파일 없음: %s - 기본값과 환경변수만 사용", path)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("설정 파일 읽기 실패: %w", err)
+	}
+
+	unmarshal := yaml.Unmarshal
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		unmarshal = json.Unmarshal
+	}
+	if err := unmarshal(data, c); err != nil {
+		return fmt.Errorf("설정 파일 형식 오류 (%s): %w", path, err)
+	}
+
+	// 봇 설정은 항목 단위로 기본값 위에 덮어씀 (일부 항목만 지정해도 나머지는 기본값 유지)
+	var bots struct {
+		Bots map[string]botProfileOverride `json:"bots" yaml:"bots"`
+	}
+	if err := unmarshal(data, &bots); err != nil {
+		return fmt.Errorf("설정 파일 형식 오류 (%s): %w", path, err)
+	}
+	for difficulty, override := range bots.Bots {
+		c.Bots[difficulty] = c.Bots[difficulty].merge(override)
+	}
+
+	log.Printf("설정 파일 로드: %s", path)
+	return nil
+}
+
+// 환경변수로 설정 덮어쓰기
+func (c *ServerConfig) applyEnv() error {
+	if v := os.Getenv("HALLIGALLI_LISTEN_ADDR"); v != "" {
+		c.ListenAddr = v
+	}
+	if v := os.Getenv("HALLIGALLI_DB_DSN"); v != "" {
+		c.DatabaseDSN = v
+	}
+	if v := os.Getenv("HALLIGALLI_ALLOWED_ORIGINS"); v != "" {
+		c.AllowedOrigins = nil
+		for _, origin := range strings.Split(v, ",") {
+			if origin = strings.TrimSpace(origin); origin != "" {
+				c.AllowedOrigins = append(c.AllowedOrigins, origin)
+			}
+		}
+	}
+	if v := os.Getenv("HALLIGALLI_BANNED_WORDS"); v != "" {
+		c.BannedWords = nil
+		for _, word := range strings.Split(v, ",") {
+			if word = strings.TrimSpace(word); word != "" {
+				c.BannedWords = append(c.BannedWords, word)
+			}
+		}
+	}
+	if v := os.Getenv("HALLIGALLI_SESSION_SECRET"); v != "" {
+		c.Session.Secret = v
+	}
+	if v := os.Getenv("GOOGLE_CLIENT_ID"); v != "" {
+		c.OAuth.GoogleClientID = v
+	}
+	if v := os.Getenv("GOOGLE_CLIENT_SECRET"); v != "" {
+		c.OAuth.GoogleClientSecret = v
+	}
+	if v := os.Getenv("GOOGLE_REDIRECT_URL"); v != "" {
+		c.OAuth.GoogleRedirectURL = v
+	}
+	if v := os.Getenv("GOOGLE_LOGIN_SUCCESS_URL"); v != "" {
+		c.OAuth.LoginSuccessURL = v
+	}
+
+	for name, target := range c.gameEnvOverrides() {
+		v := os.Getenv(name)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("환경변수 %s는 숫자여야 합니다: %q", name, v)
+		}
+		*target = n
+	}
+	return nil
+}
+
+// 서버 설정 검증
+func (c *ServerConfig) Validate() error {
+	if c.ListenAddr == "" {
+		return errors.New("서버 주소(listenAddr)가 비어있습니다")
+	}
+	if c.DatabaseDSN == "" {
+		return errors.New("DB 접속 정보(databaseDsn 또는 HALLIGALLI_DB_DSN)가 필요합니다")
+	}
+	if len(c.Session.Secret) < MinSessionSecretLength {
+		return fmt.Errorf("세션 토큰 서명 키(session.secret 또는 HALLIGALLI_SESSION_SECRET)는 %d자 이상이어야 합니다", MinSessionSecretLength)
+	}
+	if c.Session.TTLHours < 1 {
+		return errors.New("세션 토큰 유효기간(session.ttlHours)은 1시간 이상이어야 합니다")
+	}
+	if c.Leaderboard.Size < 1 || c.Leaderboard.Size > DefaultLeaderboardSize {
+		return fmt.Errorf("순위표 크기(leaderboard.size)는 1-%d 사이여야 합니다", DefaultLeaderboardSize)
+	}
+	if c.Leaderboard.RefreshSeconds < MinLeaderboardRefresh {
+		return fmt.Errorf("순위표 집계 간격(leaderboard.refreshSeconds)은 %d초 이상이어야 합니다", MinLeaderboardRefresh)
+	}
+	if c.Leaderboard.MinBellPresses < 1 {
+		return errors.New("벨 정확도 순위표 최소 벨 누른 횟수(leaderboard.minBellPresses)는 1 이상이어야 합니다")
+	}
+	if c.Latency.PingIntervalSeconds < 1 || c.Latency.PingIntervalSeconds > MaxPingInterval {
+		return fmt.Errorf("핑 간격(latency.pingIntervalSeconds)은 1-%d초 사이여야 합니다", MaxPingInterval)
+	}
+	if c.Latency.WarnMs < 1 {
+		return errors.New("지연 시간 경고 기준(latency.warnMs)은 1밀리초 이상이어야 합니다")
+	}
+	if c.Latency.DropMs != 0 && c.Latency.DropMs < c.Latency.WarnMs {
+		return errors.New("연결 종료 기준(latency.dropMs)은 0이거나 경고 기준(latency.warnMs) 이상이어야 합니다")
+	}
+	if c.Latency.DropAfter < 1 {
+		return errors.New("연결 종료까지의 측정 횟수(latency.dropAfter)는 1 이상이어야 합니다")
+	}
+	for difficulty, profile := range c.Bots {
+		if !IsValidBotDifficulty(difficulty) {
+			return fmt.Errorf("알 수 없는 봇 난이도(bots.%s)입니다 (%s, %s, %s 중 하나)", difficulty, BotDifficultyEasy, BotDifficultyNormal, BotDifficultyHard)
+		}
+		if err := profile.Validate(); err != nil {
+			return fmt.Errorf("봇 설정 오류 (bots.%s): %w", difficulty, err)
+		}
+	}
+	if c.OAuth.GoogleEnabled() {
+		if c.OAuth.GoogleClientSecret == "" {
+			return errors.New("Google 로그인을 사용하려면 클라이언트 시크릿(oauth.googleClientSecret 또는 GOOGLE_CLIENT_SECRET)이 필요합니다")
+		}
+		if !isAbsoluteURL(c.OAuth.GoogleRedirectURL) {
+			return errors.New("Google 리다이렉트 주소(oauth.googleRedirectUrl 또는 GOOGLE_REDIRECT_URL)는 http(s):// 로 시작하는 주소여야 합니다")
+		}
+		if c.OAuth.LoginSuccessURL != "" && !isAbsoluteURL(c.OAuth.LoginSuccessURL) {
+			return errors.New("로그인 성공 주소(oauth.loginSuccessUrl)는 http(s):// 로 시작하는 주소여야 합니다")
+		}
+	}
+	if err := c.Game.Validate(); err != nil {
+		return fmt.Errorf("기본 게임 설정 오류: %w", err)
+	}
+	return nil
+}
+
+// http 또는 https 절대 주소인지 확인
+func isAbsoluteURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// SIGHUP을 받으면 설정을 다시 읽어 새로 만드는 방의 기본 게임 설정만 교체
+// 진행 중인 방은 자신의 설정을 그대로 사용하며, 나머지 설정은 재시작해야 적용됨
+func WatchReload(path string, current *ServerConfig) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+
+	go func() {
+		for range signals {
+			cfg, err := Load(path)
+			if err != nil {
+				log.Printf("설정 다시 불러오기 실패 - 기존 설정 유지: %v", err)
+				continue
+			}
+
+			SetDefaultConfig(cfg.Game)
+			log.Printf("기본 게임 설정 다시 불러옴: %+v", cfg.Game)
+
+			if cfg.ListenAddr != current.ListenAddr || cfg.DatabaseDSN != current.DatabaseDSN ||
+				strings.Join(cfg.AllowedOrigins, ",") != strings.Join(current.AllowedOrigins, ",") || cfg.OAuth != current.OAuth || cfg.Session != current.Session || cfg.Leaderboard != current.Leaderboard || cfg.Latency != current.Latency || !reflect.DeepEqual(cfg.Bots, current.Bots) ||
+				strings.Join(cfg.BannedWords, ",") != strings.Join(current.BannedWords, ",") {
+				log.Printf("서버 주소, DB 접속 정보, 허용 Origin, OAuth, 세션, 순위표, 지연 시간, 봇, 금칙어 설정 변경은 서버를 재시작해야 적용됩니다")
+			}
+		}
+	}()
+}
@@ -9,9 +9,10 @@ import (
 
 var DB *sql.DB
 
-func Init() {
+// 설정에서 불러온 접속 정보로 DB 연결
+func Init(dsn string) {
 	var err error
-	DB, err = sql.Open("postgres", "user=myuser password=987654 dbname=mydb sslmode=disable")
+	DB, err = sql.Open("postgres", dsn)
 	if err != nil {
 		log.Fatal("❌ DB 연결 실패:", err)
 	}
@@ -21,4 +22,68 @@ func Init() {
 	}
 
 	log.Println("✅ DB 연결 성공")
+
+	createTables()
+}
+
+// 서버가 사용하는 테이블이 없으면 생성
+func createTables() {
+	statements := []string{
+		// 게임 계정 (아이디/비밀번호 로그인, Google 로그인으로 만든 계정은 비밀번호가 비어있음)
+		`CREATE TABLE IF NOT EXISTS Users (
+			id TEXT PRIMARY KEY,
+			password TEXT NOT NULL,
+			nickname TEXT NOT NULL
+		)`,
+		// Google 계정과 연결된 게임 계정
+		`CREATE TABLE IF NOT EXISTS google_user (
+			id TEXT PRIMARY KEY,
+			email TEXT,
+			name TEXT
+		)`,
+		`ALTER TABLE google_user ADD COLUMN IF NOT EXISTS account_id TEXT REFERENCES Users (id)`,
+		// 계정 레이팅 (기본값은 config.DefaultRating과 같아야 함)과 레이팅에 반영된 게임 수
+		`ALTER TABLE Users ADD COLUMN IF NOT EXISTS rating DOUBLE PRECISION NOT NULL DEFAULT 1500`,
+		`ALTER TABLE Users ADD COLUMN IF NOT EXISTS rated_games INTEGER NOT NULL DEFAULT 0`,
+		// 로그아웃 등으로 폐기된 세션 토큰 (만료 시각이 지나면 지워도 됨)
+		`CREATE TABLE IF NOT EXISTS revoked_sessions (
+			session_id TEXT PRIMARY KEY,
+			account_id TEXT NOT NULL,
+			expires_at TIMESTAMPTZ NOT NULL,
+			revoked_at TIMESTAMPTZ NOT NULL DEFAULT now()
+		)`,
+		// 끝난 게임 기록 (사용한 게임 설정 포함)
+		`CREATE TABLE IF NOT EXISTS matches (
+			id TEXT PRIMARY KEY,
+			room_id TEXT NOT NULL,
+			started_at TIMESTAMPTZ NOT NULL,
+			ended_at TIMESTAMPTZ NOT NULL,
+			config JSONB NOT NULL
+		)`,
+		// 게임별 플레이어 기록 (봇이나 로그인하지 않은 플레이어는 account_id가 비어있음)
+		`CREATE TABLE IF NOT EXISTS match_players (
+			match_id TEXT NOT NULL REFERENCES matches (id) ON DELETE CASCADE,
+			seat INTEGER NOT NULL,
+			account_id TEXT REFERENCES Users (id),
+			player_name TEXT NOT NULL,
+			is_bot BOOLEAN NOT NULL,
+			final_cards INTEGER NOT NULL,
+			rank INTEGER NOT NULL,
+			bell_hits INTEGER NOT NULL,
+			bell_misses INTEGER NOT NULL,
+			PRIMARY KEY (match_id, seat)
+		)`,
+		`CREATE INDEX IF NOT EXISTS match_players_account_id ON match_players (account_id)`,
+		// 가장 빠른 올바른 벨 반응 시간 (밀리초, 올바르게 누른 적이 없으면 비어있음)
+		`ALTER TABLE match_players ADD COLUMN IF NOT EXISTS fastest_ring_ms INTEGER`,
+		`CREATE INDEX IF NOT EXISTS matches_ended_at ON matches (ended_at)`,
+		// 게임 후 레이팅 변화 (레이팅에 반영되지 않은 플레이어는 비어있음)
+		`ALTER TABLE match_players ADD COLUMN IF NOT EXISTS rating_change DOUBLE PRECISION`,
+	}
+
+	for _, statement := range statements {
+		if _, err := DB.Exec(statement); err != nil {
+			log.Fatal("❌ 테이블 생성 실패:", err)
+		}
+	}
 }
@@ -1,6 +1,6 @@
 package game
 
 type Card struct {
-    FruitIndex int
-    FruitCount int
-}
\ No newline at end of file
+	FruitIndex int
+	FruitCount int
+}
@@ -1,36 +1,55 @@
 package game
 
 import (
-    "math/rand"
-    "time"
+	"math/rand"
+	"time"
 )
 
 func CreateDeck() []Card {
-    var deck []Card
-    for fruit := 0; fruit < 4; fruit++ {
-        for count := 1; count <= 5; count++ {
-            for i := 0; i < 4; i++ {
-                deck = append(deck, Card{FruitIndex: fruit, FruitCount: count})
-            }
-        }
-    }
-    return deck
+	var deck []Card
+	for fruit := 0; fruit < 4; fruit++ {
+		for count := 1; count <= 5; count++ {
+			for i := 0; i < 4; i++ {
+				deck = append(deck, Card{FruitIndex: fruit, FruitCount: count})
+			}
+		}
+	}
+	return deck
 }
 
 func ShuffleDeck(deck []Card) {
-    rand.Seed(time.Now().UnixNano())
-    rand.Shuffle(len(deck), func(i, j int) {
-        deck[i], deck[j] = deck[j], deck[i]
-    })
+	rand.Seed(time.Now().UnixNano())
+	rand.Shuffle(len(deck), func(i, j int) {
+		deck[i], deck[j] = deck[j], deck[i]
+	})
 }
 
 func DealCards(deck []Card, playerCount int) [][]Card {
-    cardsPerPlayer := len(deck) / playerCount
-    hands := make([][]Card, playerCount)
-    for i := 0; i < playerCount; i++ {
-        start := i * cardsPerPlayer
-        end := start + cardsPerPlayer
-        hands[i] = deck[start:end]
-    }
-    return hands
-}
\ No newline at end of file
+	cardsPerPlayer := len(deck) / playerCount
+	hands := make([][]Card, playerCount)
+	for i := 0; i < playerCount; i++ {
+		start := i * cardsPerPlayer
+		end := start + cardsPerPlayer
+		hands[i] = deck[start:end]
+	}
+	return hands
+}
+
+// 각 플레이어에게 지정된 장수만큼 카드 분배
+// 덱이 부족하면 덱을 균등하게 나눈 장수만큼 분배하며, 손패는 덱과 배열을 공유하지 않음
+func DealHands(deck []Card, playerCount, cardsPerPlayer int) [][]Card {
+	if playerCount <= 0 {
+		return nil
+	}
+	if maxCards := len(deck) / playerCount; cardsPerPlayer > maxCards {
+		cardsPerPlayer = maxCards
+	}
+
+	hands := make([][]Card, playerCount)
+	for i := 0; i < playerCount; i++ {
+		start := i * cardsPerPlayer
+		hands[i] = make([]Card, cardsPerPlayer)
+		copy(hands[i], deck[start:start+cardsPerPlayer])
+	}
+	return hands
+}
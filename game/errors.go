@@ -0,0 +1,16 @@
+package game
+
+import "errors"
+
+// 게임 엔진 에러
+var (
+	ErrPlayerExists       = errors.New("같은 ID의 플레이어가 이미 방에 있습니다")
+	ErrRoomFull           = errors.New("방이 꽉 찼습니다")
+	ErrGameStarted        = errors.New("게임이 이미 시작된 상태입니다")
+	ErrGameNotStarted     = errors.New("게임이 시작되지 않은 상태입니다")
+	ErrCardGameNotStarted = errors.New("카드 게임이 시작되지 않은 상태입니다")
+	ErrNotEnoughPlayers   = errors.New("게임을 시작할 플레이어가 부족합니다")
+	ErrPlayerNotSeated    = errors.New("플레이어 인덱스를 찾을 수 없습니다")
+	ErrNoCardsLeft        = errors.New("모든 플레이어가 카드를 가지고 있지 않습니다")
+	ErrBellAlreadyRung    = errors.New("이미 벨이 눌린 상태입니다")
+)
@@ -0,0 +1,46 @@
+package game
+
+import "sort"
+
+// 카드 개수로 순위 계산 (카드가 많을수록 높은 순위, 같은 개수는 공동 순위)
+func CalculateRanks(playerCards []int) []int {
+	// 플레이어 인덱스와 카드 개수를 함께 저장
+	type playerCardInfo struct {
+		playerIndex int
+		cardCount   int
+	}
+
+	// 플레이어 정보 배열 생성
+	playerInfos := make([]playerCardInfo, len(playerCards))
+	for i, cardCount := range playerCards {
+		playerInfos[i] = playerCardInfo{
+			playerIndex: i,
+			cardCount:   cardCount,
+		}
+	}
+
+	// 카드 개수 기준으로 내림차순 정렬 (카드가 많을수록 높은 순위)
+	sort.Slice(playerInfos, func(i, j int) bool {
+		return playerInfos[i].cardCount > playerInfos[j].cardCount
+	})
+
+	// 순위 배열 생성 (1등부터 시작)
+	ranks := make([]int, len(playerCards))
+
+	// 실제 순위로 업데이트 (공동 순위 처리)
+	currentRank := 1
+	currentCardCount := -1
+
+	for i, playerInfo := range playerInfos {
+		// 카드 개수가 바뀌면 순위 증가
+		if playerInfo.cardCount != currentCardCount {
+			currentRank = i + 1
+			currentCardCount = playerInfo.cardCount
+		}
+
+		// 현재 순위를 해당 플레이어에게 할당
+		ranks[playerInfo.playerIndex] = currentRank
+	}
+
+	return ranks
+}
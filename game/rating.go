@@ -0,0 +1,35 @@
+package game
+
+import "math"
+
+// 순위로 레이팅 갱신 (모든 두 명 쌍을 1:1 Elo 대결로 보고 결과를 평균)
+// 순위가 높으면 이긴 것, 같으면 비긴 것으로 계산하며, 두 명 미만이면 그대로 반환
+func UpdateRatings(ratings []float64, ranks []int, k float64) []float64 {
+	updated := make([]float64, len(ratings))
+	copy(updated, ratings)
+
+	n := len(ratings)
+	if n < 2 {
+		return updated
+	}
+
+	for i := range ratings {
+		var sum float64
+		for j := range ratings {
+			if i == j {
+				continue
+			}
+
+			expected := 1 / (1 + math.Pow(10, (ratings[j]-ratings[i])/400))
+			actual := 0.5
+			if ranks[i] < ranks[j] {
+				actual = 1
+			} else if ranks[i] > ranks[j] {
+				actual = 0
+			}
+			sum += actual - expected
+		}
+		updated[i] = ratings[i] + k*sum/float64(n-1)
+	}
+	return updated
+}
@@ -0,0 +1,106 @@
+package game
+
+import (
+	"math"
+	"testing"
+)
+
+func TestUpdateRatings(t *testing.T) {
+	const k = 32
+
+	tests := []struct {
+		name       string
+		ratings    []float64
+		ranks      []int
+		wantDeltas []float64
+	}{
+		{
+			name:       "한 명은 그대로",
+			ratings:    []float64{1500},
+			ranks:      []int{1},
+			wantDeltas: []float64{0},
+		},
+		{
+			name:       "같은 레이팅 두 명",
+			ratings:    []float64{1500, 1500},
+			ranks:      []int{1, 2},
+			wantDeltas: []float64{16, -16},
+		},
+		{
+			name:       "같은 레이팅 두 명 무승부",
+			ratings:    []float64{1500, 1500},
+			ranks:      []int{1, 1},
+			wantDeltas: []float64{0, 0},
+		},
+		{
+			// 400점 차이면 높은 쪽의 기대 승률은 10/11
+			name:       "다른 레이팅 무승부는 낮은 쪽이 오름",
+			ratings:    []float64{1900, 1500},
+			ranks:      []int{1, 1},
+			wantDeltas: []float64{k * (0.5 - 10.0/11), k * (0.5 - 1.0/11)},
+		},
+		{
+			name:       "높은 레이팅이 지면 크게 내려감",
+			ratings:    []float64{1900, 1500},
+			ranks:      []int{2, 1},
+			wantDeltas: []float64{k * (0 - 10.0/11), k * (1 - 1.0/11)},
+		},
+		{
+			// 쌍마다의 결과를 (인원 - 1)로 나눠 평균
+			name:       "같은 레이팅 네 명",
+			ratings:    []float64{1500, 1500, 1500, 1500},
+			ranks:      []int{1, 2, 3, 4},
+			wantDeltas: []float64{16, 16.0 / 3, -16.0 / 3, -16},
+		},
+		{
+			name:       "같은 레이팅 세 명 중 두 명 공동 1등",
+			ratings:    []float64{1500, 1500, 1500},
+			ranks:      []int{1, 1, 3},
+			wantDeltas: []float64{8, 8, -16},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ratings := append([]float64(nil), tt.ratings...)
+			updated := UpdateRatings(ratings, tt.ranks, k)
+
+			for i := range tt.ratings {
+				if ratings[i] != tt.ratings[i] {
+					t.Fatalf("입력 레이팅이 바뀜: %v", ratings)
+				}
+				delta := updated[i] - tt.ratings[i]
+				if math.Abs(delta-tt.wantDeltas[i]) > 1e-9 {
+					t.Errorf("플레이어 %d 변화 = %v, want %v", i, delta, tt.wantDeltas[i])
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateRatingsIsZeroSum(t *testing.T) {
+	tests := []struct {
+		name    string
+		ratings []float64
+		ranks   []int
+	}{
+		{"두 명", []float64{1620, 1480}, []int{2, 1}},
+		{"세 명", []float64{1350, 1500, 1810}, []int{1, 3, 2}},
+		{"네 명 공동 순위", []float64{1200, 1550, 1550, 2000}, []int{2, 1, 2, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated := UpdateRatings(tt.ratings, tt.ranks, 32)
+
+			var before, after float64
+			for i := range tt.ratings {
+				before += tt.ratings[i]
+				after += updated[i]
+			}
+			if math.Abs(after-before) > 1e-9 {
+				t.Errorf("레이팅 합 %v -> %v, 합이 유지되어야 함", before, after)
+			}
+		})
+	}
+}
@@ -1,21 +1,43 @@
 package game
 
 import (
+	"log"
+	"math/rand"
 	"sync"
 	"time"
+
+	"main/config"
 )
 
-// 단일 방 구조체
+// 게임 방 상태 (네트워크와 무관한 게임 규칙 엔진)
 type Room struct {
-	mu             sync.RWMutex
-	Players        []*Player
-	TurnIndex      int
-	TotalCardCount int
-	GameStarted    bool
-	RevealedCards  []Card
-	PlayerHands    [][]Card
-	Deck           []Card
-	LastActivity   time.Time
+	mu            sync.RWMutex
+	players       map[string]*Player // 플레이어 ID -> 플레이어
+	config        config.GameConfig  // 방의 게임 설정 (방 생성 후 바뀌지 않음)
+	isGameStarted bool
+	readyPlayers  map[string]bool // 게임 화면 준비를 마친 플레이어들
+	// 좌석 정보 (게임 시작 시 설정)
+	seats         []string       // 좌석 순서대로의 플레이어 ID
+	playerIndexes map[string]int // 플레이어 ID -> 인덱스 매핑
+	// 카드 공개 관련 상태
+	isCardGameStarted  bool     // 카드 게임이 시작되었는지
+	currentPlayerIndex int      // 현재 카드를 낼 플레이어 인덱스
+	playerHands        [][]Card // 각 플레이어의 손패 (앞쪽이 맨 위 카드)
+	openedCards        [][]Card // 각 플레이어가 공개한 카드 더미 (마지막이 맨 위 카드)
+	publicFruitIndexes []int    // 각 플레이어의 공개된 카드 과일 인덱스
+	publicFruitCounts  []int    // 각 플레이어의 공개된 카드 과일 개수
+	revealCount        int      // 지금까지 공개된 카드 수 (공개 시점 구분용)
+	// 벨 누르기 관련 상태
+	bellRung   bool  // 벨이 눌렸는지 여부 (새로운 카드 공개 전까지 유지)
+	bellHits   []int // 각 플레이어가 올바르게 벨을 누른 횟수
+	bellMisses []int // 각 플레이어가 잘못 벨을 누른 횟수
+	// 각 플레이어의 가장 빠른 올바른 벨 반응 시간 (마지막 카드 공개부터, 밀리초, 0이면 기록 없음)
+	fastestRings []int
+	lastRevealAt time.Time // 마지막 카드 공개 시각
+	// 게임 제한시간 관련 상태
+	isTimeExpired bool // 시간제한이 끝났는지 여부
+	startedAt     time.Time
+	lastActivity  time.Time
 }
 
 // 플레이어 구조체
@@ -24,145 +46,779 @@ type Player struct {
 	Username string `json:"username"`
 	IsReady  bool   `json:"isReady"`
 	IsActive bool   `json:"isActive"`
+	IsBot    bool   `json:"isBot"` // 서버가 조작하는 봇 플레이어인지
+}
+
+// 게임 시작 결과
+type StartResult struct {
+	PlayerIDs     []string // 좌석 순서대로의 플레이어 ID
+	PlayerNames   []string // 좌석 순서대로의 플레이어 이름
+	StartingCards int      // 각 플레이어가 받은 카드 수
+}
+
+// 게임 화면 준비 결과
+type ReadyResult struct {
+	ReadyCount   int  // 준비를 마친 연결된 플레이어 수
+	TotalPlayers int  // 연결된 플레이어 수 (연결이 끊긴 좌석은 기다리지 않음)
+	AllReady     bool // 이번 준비로 연결된 플레이어가 모두 준비를 마쳐 카드 게임이 시작되었는지
+}
+
+// 카드 공개 결과
+type OpenCardResult struct {
+	PlayerIndex     int  // 카드를 낸 플레이어 인덱스
+	Card            Card // 공개된 카드
+	RevealCount     int  // 이번 공개까지의 누적 공개 수
+	BellRingingTime bool // 이번 공개로 종을 칠 수 있는 타이밍이 되었는지
+}
+
+// 벨 누르기 결과
+type BellResult struct {
+	PlayerIndex int    // 벨을 누른 플레이어 인덱스
+	Correct     bool   // 종을 칠 수 있는 타이밍이었는지
+	CardGivenTo []bool // 잘못 누른 경우 카드를 받은 플레이어들
+	PlayerCards []int  // 벨 처리 후 각 플레이어별 손패 카드 개수
+	GameOver    bool   // 시간제한 이후 올바르게 눌러 게임이 끝나야 하는지
+	ReactionMs  int    // 마지막 카드 공개부터 벨 누름을 받기까지 걸린 시간 (밀리초, 알 수 없으면 0)
+}
+
+// 게임 종료 결과 (좌석 인덱스 기반)
+type GameResult struct {
+	PlayerIDs   []string  // 좌석 순서대로의 플레이어 ID
+	PlayerNames []string  // 좌석 순서대로의 플레이어 이름
+	IsBot       []bool    // 각 플레이어가 봇인지
+	PlayerCards []int     // 각 플레이어의 최종 카드 개수
+	PlayerRanks []int     // 각 플레이어의 순위 (1등부터 시작)
+	BellHits    []int     // 각 플레이어가 올바르게 벨을 누른 횟수
+	BellMisses  []int     // 각 플레이어가 잘못 벨을 누른 횟수
+	FastestRing []int     // 각 플레이어의 가장 빠른 올바른 벨 반응 시간 (밀리초, 0이면 기록 없음)
+	StartedAt   time.Time // 게임 시작 시각
+}
+
+// 게임 상태 스냅샷 (재접속/동기화용, 좌석 인덱스 기반)
+type Snapshot struct {
+	IsGameStarted      bool
+	IsCardGameStarted  bool
+	PlayerIDs          []string // 좌석 순서대로의 플레이어 ID
+	PlayerNames        []string // 좌석 순서대로의 플레이어 이름
+	PlayerActive       []bool   // 각 플레이어의 연결 여부
+	PlayerCards        []int    // 각 플레이어별 손패 카드 개수
+	OpenCards          []int    // 각 플레이어가 공개한 카드 개수
+	PublicFruitIndexes []int    // 각 플레이어의 공개된 카드 과일 인덱스
+	PublicFruitCounts  []int    // 각 플레이어의 공개된 카드 과일 개수
+	CurrentPlayerIndex int      // 다음에 카드를 낼 플레이어 인덱스
+	BellRung           bool     // 현재 공개된 카드에 대해 벨이 눌렸는지
+	IsTimeExpired      bool     // 시간제한이 끝났는지
 }
 
-// 전역 단일 방 인스턴스
-var GlobalRoom = &Room{
-	Players:       make([]*Player, 0),
-	RevealedCards: make([]Card, 0),
-	LastActivity:  time.Now(),
+// 새로운 게임 방 생성 (검증된 게임 설정 사용)
+func NewRoom(cfg config.GameConfig) *Room {
+	return &Room{
+		players:      make(map[string]*Player),
+		config:       cfg,
+		lastActivity: time.Now(),
+	}
 }
 
 // 방에 플레이어 추가
-func (r *Room) AddPlayer(clientID, username string) *Player {
+func (r *Room) AddPlayer(playerID, username string) (*Player, error) {
+	return r.addPlayer(playerID, username, false)
+}
+
+// 방에 봇 플레이어 추가
+func (r *Room) AddBot(botID, username string) (*Player, error) {
+	return r.addPlayer(botID, username, true)
+}
+
+// 방에 플레이어 또는 봇 추가
+func (r *Room) addPlayer(playerID, username string, isBot bool) (*Player, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	// 이미 존재하는 플레이어인지 확인
-	for _, player := range r.Players {
-		if player.ID == clientID {
-			player.Username = username
-			player.IsActive = true
-			return player
-		}
+	if _, exists := r.players[playerID]; exists {
+		return nil, ErrPlayerExists
+	}
+	if len(r.players) >= r.config.MaxPlayers {
+		return nil, ErrRoomFull
+	}
+	if r.isGameStarted {
+		return nil, ErrGameStarted
 	}
 
-	// 새 플레이어 추가
 	player := &Player{
-		ID:       clientID,
+		ID:       playerID,
 		Username: username,
-		IsReady:  false,
+		IsReady:  isBot, // 봇은 항상 준비된 상태
 		IsActive: true,
+		IsBot:    isBot,
 	}
-	r.Players = append(r.Players, player)
-	r.LastActivity = time.Now()
-	return player
+	r.players[playerID] = player
+	r.lastActivity = time.Now()
+	return player, nil
 }
 
-// 방에서 플레이어 제거
-func (r *Room) RemovePlayer(clientID string) {
+// 방에서 플레이어 제거 (남은 플레이어 수 반환)
+func (r *Room) RemovePlayer(playerID string) int {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	for i, player := range r.Players {
-		if player.ID == clientID {
-			player.IsActive = false
-			// 비활성 플레이어 제거
-			r.Players = append(r.Players[:i], r.Players[i+1:]...)
-			break
-		}
+	if player, exists := r.players[playerID]; exists {
+		player.IsActive = false
+		delete(r.players, playerID)
+	}
+	r.lastActivity = time.Now()
+	return len(r.players)
+}
+
+// 플레이어가 방에 있는지 확인
+func (r *Room) HasPlayer(playerID string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, exists := r.players[playerID]
+	return exists
+}
+
+// 현재 플레이어 수
+func (r *Room) PlayerCount() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.players)
+}
+
+// 최대 플레이어 수
+func (r *Room) MaxPlayers() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.config.MaxPlayers
+}
+
+// 게임을 시작하기 위한 최소 플레이어 수
+func (r *Room) MinPlayers() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.config.MinPlayers
+}
+
+// 방의 게임 설정 조회
+func (r *Room) Config() config.GameConfig {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.config
+}
+
+// 게임이 시작되었는지 확인
+func (r *Room) IsGameStarted() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.isGameStarted
+}
+
+// 모두 준비를 마쳐 카드 게임이 시작되었는지 확인
+func (r *Room) IsCardGameStarted() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.isCardGameStarted
+}
+
+// 게임이 시작되지 않았고 빈 자리가 있는지 확인
+func (r *Room) IsJoinable() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return !r.isGameStarted && len(r.players) < r.config.MaxPlayers
+}
+
+// 플레이어 연결 상태 변경 (게임 중 연결 해제/재접속 시 사용)
+func (r *Room) SetPlayerActive(playerID string, isActive bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if player, exists := r.players[playerID]; exists {
+		player.IsActive = isActive
+		r.lastActivity = time.Now()
 	}
-	r.LastActivity = time.Now()
+}
+
+// 플레이어 이름 변경 (닉네임 변경 시 사용, 플레이어가 없으면 false)
+func (r *Room) RenamePlayer(playerID, username string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	player, exists := r.players[playerID]
+	if !exists {
+		return false
+	}
+	player.Username = username
+	return true
 }
 
 // 플레이어 준비 상태 변경
-func (r *Room) TogglePlayerReady(clientID string) bool {
+func (r *Room) TogglePlayerReady(playerID string) bool {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	for _, player := range r.Players {
-		if player.ID == clientID {
-			player.IsReady = !player.IsReady
-			r.LastActivity = time.Now()
-			return player.IsReady
-		}
+	if player, exists := r.players[playerID]; exists {
+		player.IsReady = !player.IsReady
+		r.lastActivity = time.Now()
+		return player.IsReady
 	}
 	return false
 }
 
-// 게임 시작 가능 여부 확인
+// 대기실에서 준비를 마친 플레이어 수와 전체 플레이어 수
+func (r *Room) LobbyReadyCount() (int, int) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	readyCount := 0
+	for _, player := range r.players {
+		if player.IsReady {
+			readyCount++
+		}
+	}
+	return readyCount, len(r.players)
+}
+
+// 게임 시작 가능 여부 확인 (최소 인원 이상이 모두 준비)
 func (r *Room) CanStartGame() bool {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
+	return r.canStartGameLocked()
+}
 
-	if len(r.Players) < 2 {
+// 게임 시작 가능 여부 확인 (뮤텍스가 이미 잠겨있는 경우를 위한 내부 함수)
+func (r *Room) canStartGameLocked() bool {
+	if len(r.players) < r.config.MinPlayers {
 		return false
 	}
 
-	allReady := true
-	for _, player := range r.Players {
+	for _, player := range r.players {
 		if !player.IsReady {
-			allReady = false
-			break
+			return false
 		}
 	}
+	return true
+}
+
+// 게임 시작 (좌석을 랜덤하게 정하고 섞은 덱에서 설정된 장수만큼 카드 분배)
+func (r *Room) StartGame() (*StartResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.isGameStarted {
+		return nil, ErrGameStarted
+	}
+	if len(r.players) == 0 || len(r.players) < r.config.MinPlayers {
+		return nil, ErrNotEnoughPlayers
+	}
 
-	return allReady
+	r.isGameStarted = true
+	r.readyPlayers = make(map[string]bool)
+
+	// 플레이어 ID를 랜덤하게 섞어 좌석 결정
+	r.seats = make([]string, 0, len(r.players))
+	for playerID := range r.players {
+		r.seats = append(r.seats, playerID)
+	}
+	rand.Shuffle(len(r.seats), func(i, j int) {
+		r.seats[i], r.seats[j] = r.seats[j], r.seats[i]
+	})
+
+	playerNames := make([]string, len(r.seats))
+	r.playerIndexes = make(map[string]int, len(r.seats))
+	for i, playerID := range r.seats {
+		r.playerIndexes[playerID] = i
+		playerNames[i] = r.players[playerID].Username
+	}
+
+	// 섞은 덱에서 각 플레이어에게 카드 분배
+	deck := CreateDeck()
+	ShuffleDeck(deck)
+	r.playerHands = DealHands(deck, len(r.seats), r.config.StartingCards)
+
+	// 공개된 카드 배열 초기화 (-1은 아직 카드가 공개되지 않음)
+	r.openedCards = make([][]Card, len(r.seats))
+	r.publicFruitIndexes = make([]int, len(r.seats))
+	r.publicFruitCounts = make([]int, len(r.seats))
+	for i := range r.publicFruitIndexes {
+		r.publicFruitIndexes[i] = -1
+		r.publicFruitCounts[i] = -1
+	}
+
+	r.isCardGameStarted = false
+	r.currentPlayerIndex = 0
+	r.revealCount = 0
+	r.bellRung = false
+	r.bellHits = make([]int, len(r.seats))
+	r.bellMisses = make([]int, len(r.seats))
+	r.fastestRings = make([]int, len(r.seats))
+	r.lastRevealAt = time.Time{}
+	r.isTimeExpired = false
+	r.startedAt = time.Now()
+	r.lastActivity = r.startedAt
+
+	seats := make([]string, len(r.seats))
+	copy(seats, r.seats)
+
+	return &StartResult{
+		PlayerIDs:     seats,
+		PlayerNames:   playerNames,
+		StartingCards: len(r.playerHands[0]),
+	}, nil
 }
 
-// 게임 시작
-func (r *Room) StartGame() bool {
+// 플레이어의 게임 화면 준비 완료 처리 (연결된 플레이어가 모두 준비되면 카드 게임 시작)
+func (r *Room) MarkReady(playerID string) (*ReadyResult, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if !r.CanStartGame() {
+	if !r.isGameStarted {
+		return nil, ErrGameNotStarted
+	}
+	if _, exists := r.playerIndexes[playerID]; !exists {
+		return nil, ErrPlayerNotSeated
+	}
+
+	r.readyPlayers[playerID] = true
+	result := r.startCardGameIfReadyLocked()
+	r.lastActivity = time.Now()
+
+	return result, nil
+}
+
+// 준비 단계에서 연결이 끊긴 플레이어를 빼고 다시 확인해 남은 플레이어가 모두 준비됐으면 카드 게임 시작
+// (카드 게임을 이번에 시작했으면 true)
+func (r *Room) StartCardGameIfReady() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.isGameStarted {
 		return false
 	}
+	return r.startCardGameIfReadyLocked().AllReady
+}
+
+// 연결된 좌석의 준비 상태를 집계하고, 모두 준비됐으면 첫 번째 플레이어부터 카드 게임 시작 (잠금 상태에서 호출)
+func (r *Room) startCardGameIfReadyLocked() *ReadyResult {
+	result := &ReadyResult{}
+	for _, playerID := range r.seats {
+		if player, exists := r.players[playerID]; !exists || !player.IsActive {
+			continue
+		}
+		result.TotalPlayers++
+		if r.readyPlayers[playerID] {
+			result.ReadyCount++
+		}
+	}
 
-	r.GameStarted = true
-	r.TurnIndex = 0
-	r.TotalCardCount = len(r.Deck)
+	if !r.isCardGameStarted && result.TotalPlayers > 0 && result.ReadyCount == result.TotalPlayers {
+		r.isCardGameStarted = true
+		r.currentPlayerIndex = 0
+		result.AllReady = true
+	}
+	return result
+}
 
-	// 카드 분배
-	r.PlayerHands = DealCards(r.Deck, len(r.Players))
-	r.LastActivity = time.Now()
+// 좌석 순서상 현재 차례인 플레이어의 맨 위 카드 공개
+// 카드를 가진 플레이어가 한 명도 없으면 ErrNoCardsLeft 반환
+func (r *Room) OpenCard() (*OpenCardResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
-	return true
+	if !r.isCardGameStarted {
+		return nil, ErrCardGameNotStarted
+	}
+
+	totalPlayers := len(r.seats)
+	if totalPlayers == 0 {
+		return nil, ErrNotEnoughPlayers
+	}
+
+	// 카드를 가진 플레이어를 찾을 때까지 순환
+	playerIndex := r.currentPlayerIndex
+	originalPlayerIndex := playerIndex
+	for len(r.playerHands[playerIndex]) == 0 {
+		r.currentPlayerIndex = (r.currentPlayerIndex + 1) % totalPlayers
+		playerIndex = r.currentPlayerIndex
+
+		// 한 바퀴 돌았는데도 카드를 가진 플레이어가 없음
+		if playerIndex == originalPlayerIndex {
+			return nil, ErrNoCardsLeft
+		}
+	}
+
+	// 플레이어 손패 맨 위 카드를 꺼내 공개 더미에 올림
+	card := r.playerHands[playerIndex][0]
+	r.playerHands[playerIndex] = r.playerHands[playerIndex][1:]
+	r.openedCards[playerIndex] = append(r.openedCards[playerIndex], card)
+
+	// 해당 플레이어의 공개된 카드 정보 업데이트
+	r.publicFruitIndexes[playerIndex] = card.FruitIndex
+	r.publicFruitCounts[playerIndex] = card.FruitCount
+
+	// 다음 플레이어로 순환 (카드를 낸 후)
+	r.currentPlayerIndex = (r.currentPlayerIndex + 1) % totalPlayers
+
+	// 벨 누르기 상태 리셋 (새로운 카드가 공개됨)
+	r.bellRung = false
+	r.revealCount++
+	r.lastActivity = time.Now()
+	r.lastRevealAt = r.lastActivity
+
+	return &OpenCardResult{
+		PlayerIndex:     playerIndex,
+		Card:            card,
+		RevealCount:     r.revealCount,
+		BellRingingTime: r.isBellRingingTimeLocked(),
+	}, nil
 }
 
-// 게임 종료
-func (r *Room) EndGame() {
+// 벨 누르기 처리 (pressedAt은 벨 누름을 받은 시각으로 반응 시간 계산에 사용)
+// 올바른 타이밍이면 공개된 모든 카드를 가져오고, 아니면 다른 플레이어들에게 카드를 한 장씩 나누어 줌
+func (r *Room) RingBell(playerID string, pressedAt time.Time) (*BellResult, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	r.GameStarted = false
-	r.TurnIndex = 0
-	r.RevealedCards = make([]Card, 0)
-	r.PlayerHands = make([][]Card, 0)
-	r.Deck = make([]Card, 0)
-	r.LastActivity = time.Now()
+	if !r.isGameStarted {
+		return nil, ErrGameNotStarted
+	}
+	// 모두 준비를 마치기 전에는 벨을 누를 수 없음 (벌칙 카드도 내지 않음)
+	if !r.isCardGameStarted {
+		return nil, ErrCardGameNotStarted
+	}
+
+	playerIndex, exists := r.playerIndexes[playerID]
+	if !exists {
+		return nil, ErrPlayerNotSeated
+	}
+
+	// 이미 벨이 눌렸는지 확인
+	if r.bellRung {
+		return nil, ErrBellAlreadyRung
+	}
+	r.bellRung = true
+	r.lastActivity = time.Now()
+
+	result := &BellResult{
+		PlayerIndex: playerIndex,
+		Correct:     r.isBellRingingTimeLocked(),
+		ReactionMs:  r.reactionMsLocked(pressedAt),
+	}
+
+	if result.Correct {
+		r.bellHits[playerIndex]++
+		r.recordRingTimeLocked(playerIndex, result.ReactionMs)
+		r.addAllPublicCardsToPlayer(playerIndex)
+		result.GameOver = r.isTimeExpired
+	} else {
+		r.bellMisses[playerIndex]++
+		result.CardGivenTo = r.distributeCardsFromPlayer(playerIndex)
+	}
+	result.PlayerCards = r.playerCardCounts()
+
+	return result, nil
+}
+
+// 마지막 카드 공개부터 pressedAt까지의 반응 시간 (밀리초)
+// 공개된 카드가 없거나 1밀리초 미만이면 (공개 전에 받은 누름) 기록하지 않도록 0
+func (r *Room) reactionMsLocked(pressedAt time.Time) int {
+	if r.lastRevealAt.IsZero() {
+		return 0
+	}
+
+	reactionMs := int(pressedAt.Sub(r.lastRevealAt).Milliseconds())
+	if reactionMs < 1 {
+		return 0
+	}
+	return reactionMs
+}
+
+// 올바른 벨 반응 시간 기록 (가장 빠른 기록만 유지)
+func (r *Room) recordRingTimeLocked(playerIndex, reactionMs int) {
+	if reactionMs == 0 {
+		return
+	}
+	if r.fastestRings[playerIndex] == 0 || reactionMs < r.fastestRings[playerIndex] {
+		r.fastestRings[playerIndex] = reactionMs
+	}
+}
+
+// 게임 제한시간 종료 처리 (이후 누군가 올바르게 종을 치면 게임 종료)
+func (r *Room) ExpireTime() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.isTimeExpired = true
+}
+
+// 게임 종료 (공개된 카드를 손패로 되돌리고 순위 계산 후 방 초기화)
+func (r *Room) Finish() (*GameResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.isGameStarted {
+		return nil, ErrGameNotStarted
+	}
+
+	// 각 플레이어가 공개한 카드를 자신의 손패로 되돌리기
+	r.returnOpenCardsToPlayers()
+
+	playerCards := r.playerCardCounts()
+	result := &GameResult{
+		PlayerIDs:   r.seats,
+		PlayerNames: make([]string, len(r.seats)),
+		IsBot:       make([]bool, len(r.seats)),
+		PlayerCards: playerCards,
+		PlayerRanks: CalculateRanks(playerCards),
+		BellHits:    r.bellHits,
+		BellMisses:  r.bellMisses,
+		FastestRing: r.fastestRings,
+		StartedAt:   r.startedAt,
+	}
+	for i, playerID := range r.seats {
+		if player, exists := r.players[playerID]; exists {
+			result.PlayerNames[i] = player.Username
+			result.IsBot[i] = player.IsBot
+		}
+	}
+
+	r.resetLocked()
+	return result, nil
+}
+
+// 게임 상태와 플레이어를 모두 초기화
+func (r *Room) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.resetLocked()
+}
+
+// 게임 상태 초기화 (뮤텍스가 이미 잠겨있는 경우를 위한 내부 함수)
+func (r *Room) resetLocked() {
+	r.isGameStarted = false
+	r.isCardGameStarted = false
+	r.readyPlayers = nil
+	r.seats = nil
+	r.playerIndexes = nil
+	r.currentPlayerIndex = 0
+	r.revealCount = 0
+	r.playerHands = nil
+	r.openedCards = nil
+	r.publicFruitIndexes = nil
+	r.publicFruitCounts = nil
+	r.bellRung = false
+	r.bellHits = nil
+	r.bellMisses = nil
+	r.fastestRings = nil
+	r.lastRevealAt = time.Time{}
+	r.isTimeExpired = false
+	r.startedAt = time.Time{}
+	r.players = make(map[string]*Player)
+	r.lastActivity = time.Now()
+}
+
+// 지금까지 공개된 카드 수 조회 (봇이 반응하려던 공개 시점이 지났는지 확인할 때 사용)
+func (r *Room) RevealCount() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.revealCount
+}
+
+// 현재 공개된 카드에 대해 벨이 이미 눌렸는지 확인
+func (r *Room) IsBellRung() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.bellRung
+}
+
+// 플레이어 ID로 좌석 인덱스 조회
+func (r *Room) PlayerIndex(playerID string) (int, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	playerIndex, exists := r.playerIndexes[playerID]
+	return playerIndex, exists
+}
+
+// 현재 게임 상태 스냅샷 생성
+func (r *Room) Snapshot() *Snapshot {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	snapshot := &Snapshot{
+		IsGameStarted:      r.isGameStarted,
+		IsCardGameStarted:  r.isCardGameStarted,
+		PlayerIDs:          make([]string, len(r.seats)),
+		PlayerNames:        make([]string, len(r.seats)),
+		PlayerActive:       make([]bool, len(r.seats)),
+		PlayerCards:        r.playerCardCounts(),
+		OpenCards:          make([]int, len(r.openedCards)),
+		PublicFruitIndexes: make([]int, len(r.publicFruitIndexes)),
+		PublicFruitCounts:  make([]int, len(r.publicFruitCounts)),
+		CurrentPlayerIndex: r.currentPlayerIndex,
+		BellRung:           r.bellRung,
+		IsTimeExpired:      r.isTimeExpired,
+	}
+
+	for i, playerID := range r.seats {
+		snapshot.PlayerIDs[i] = playerID
+		if player, exists := r.players[playerID]; exists {
+			snapshot.PlayerNames[i] = player.Username
+			snapshot.PlayerActive[i] = player.IsActive
+		}
+	}
+	for i, pile := range r.openedCards {
+		snapshot.OpenCards[i] = len(pile)
+	}
+	copy(snapshot.PublicFruitIndexes, r.publicFruitIndexes)
+	copy(snapshot.PublicFruitCounts, r.publicFruitCounts)
+
+	return snapshot
+}
+
+// 플레이어 인덱스로 카드 개수 조회
+func (r *Room) GetPlayerCardCount(playerIndex int) int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if playerIndex < 0 || playerIndex >= len(r.playerHands) {
+		return 0
+	}
+	return len(r.playerHands[playerIndex])
 }
 
-// 방 정보 가져오기
-func (r *Room) GetRoomInfo() map[string]interface{} {
+// 모든 플레이어의 공개된 카드 정보 조회
+func (r *Room) GetAllPublicCards() ([]int, []int) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	players := make([]map[string]interface{}, 0, len(r.Players))
-	for _, player := range r.Players {
-		players = append(players, map[string]interface{}{
-			"id":       player.ID,
-			"username": player.Username,
-			"isReady":  player.IsReady,
-			"isActive": player.IsActive,
+	fruitIndexes := make([]int, len(r.publicFruitIndexes))
+	fruitCounts := make([]int, len(r.publicFruitCounts))
+	copy(fruitIndexes, r.publicFruitIndexes)
+	copy(fruitCounts, r.publicFruitCounts)
+
+	return fruitIndexes, fruitCounts
+}
+
+// 같은 종류의 과일이 정확히 설정된 개수만큼 공개되어 있는지 확인
+func (r *Room) IsBellRingingTime() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.isBellRingingTimeLocked()
+}
+
+// 종을 칠 수 있는 타이밍인지 확인 (뮤텍스가 이미 잠겨있는 경우를 위한 내부 함수)
+func (r *Room) isBellRingingTimeLocked() bool {
+	// 각 과일 종류별로 개수를 세기
+	fruitCounts := make(map[int]int)
+
+	for i, fruitIndex := range r.publicFruitIndexes {
+		// 카드가 공개되지 않은 경우 (-1) 무시
+		if fruitIndex == -1 {
+			continue
+		}
+
+		// 해당 과일의 개수에 현재 카드의 과일 개수를 더함
+		fruitCounts[fruitIndex] += r.publicFruitCounts[i]
+	}
+
+	// 어떤 과일이라도 정확히 설정된 개수가 있으면 true 반환
+	for _, count := range fruitCounts {
+		if count == r.config.BellRingingFruitCount {
+			return true
+		}
+	}
+
+	return false
+}
+
+// 특정 과일 종류가 정확히 설정된 개수만큼 공개되어 있는지 확인
+func (r *Room) IsSpecificFruitBellRingingTime(fruitIndex int) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	totalCount := 0
+
+	for i, publicFruitIndex := range r.publicFruitIndexes {
+		// 지정된 과일 종류와 일치하는 경우 개수에 추가 (공개되지 않은 -1은 제외됨)
+		if publicFruitIndex == fruitIndex {
+			totalCount += r.publicFruitCounts[i]
+		}
+	}
+
+	return totalCount == r.config.BellRingingFruitCount
+}
+
+// 각 플레이어별 손패 카드 개수 배열
+func (r *Room) playerCardCounts() []int {
+	counts := make([]int, len(r.playerHands))
+	for i, hand := range r.playerHands {
+		counts[i] = len(hand)
+	}
+	return counts
+}
+
+// 공개된 모든 카드를 특정 플레이어의 손패 맨 아래에 추가
+func (r *Room) addAllPublicCardsToPlayer(playerIndex int) {
+	if playerIndex >= 0 && playerIndex < len(r.playerHands) {
+		totalCards := 0
+		for i := 0; i < len(r.openedCards); i++ {
+			totalCards += len(r.openedCards[i])
+			r.playerHands[playerIndex] = append(r.playerHands[playerIndex], r.openedCards[i]...)
+		}
+		log.Printf("플레이어 %d의 손패에 공개된 모든 카드 %d장 추가", playerIndex, totalCards)
+	}
+
+	// 공개된 카드 정보 초기화
+	for i := 0; i < len(r.publicFruitIndexes); i++ {
+		r.publicFruitIndexes[i] = -1
+		r.publicFruitCounts[i] = -1
+		r.openedCards[i] = nil
+	}
+}
+
+// 벨을 잘못 친 플레이어가 다른 플레이어들에게 카드를 나누어주는 함수
+func (r *Room) distributeCardsFromPlayer(playerIndex int) []bool {
+	totalPlayers := len(r.playerHands)
+	if totalPlayers == 0 || playerIndex < 0 || playerIndex >= totalPlayers {
+		return make([]bool, totalPlayers)
+	}
+
+	// 카드를 받을 플레이어들 (벨을 친 플레이어 제외)
+	receivers := make([]int, 0)
+	for i := 0; i < totalPlayers; i++ {
+		if i != playerIndex {
+			receivers = append(receivers, i)
+		}
+	}
+
+	// 카드가 부족한 경우, 랜덤하게 선택된 플레이어들에게만 나누어줌
+	availableCards := len(r.playerHands[playerIndex])
+	if availableCards < len(receivers) {
+		rand.Shuffle(len(receivers), func(i, j int) {
+			receivers[i], receivers[j] = receivers[j], receivers[i]
 		})
+		receivers = receivers[:availableCards]
+	}
+
+	// 손패 맨 위 카드를 받는 플레이어의 손패 맨 아래로 이동
+	cardGivenTo := make([]bool, totalPlayers)
+	for _, receiverIndex := range receivers {
+		card := r.playerHands[playerIndex][0]
+		r.playerHands[playerIndex] = r.playerHands[playerIndex][1:]
+		r.playerHands[receiverIndex] = append(r.playerHands[receiverIndex], card)
+		cardGivenTo[receiverIndex] = true
+		log.Printf("플레이어 %d가 플레이어 %d에게 카드 1장 전달", playerIndex, receiverIndex)
 	}
 
-	return map[string]interface{}{
-		"players":       players,
-		"gameStarted":   r.GameStarted,
-		"turnIndex":     r.TurnIndex,
-		"totalCards":    r.TotalCardCount,
-		"revealedCards": len(r.RevealedCards),
+	return cardGivenTo
+}
+
+// 공개된 카드를 각 플레이어의 손패로 되돌리는 함수
+func (r *Room) returnOpenCardsToPlayers() {
+	for i := 0; i < len(r.playerHands) && i < len(r.openedCards); i++ {
+		if len(r.openedCards[i]) > 0 {
+			r.playerHands[i] = append(r.playerHands[i], r.openedCards[i]...)
+			log.Printf("플레이어 %d의 공개된 카드 %d장을 손패로 되돌림", i, len(r.openedCards[i]))
+		}
+		r.openedCards[i] = nil
 	}
 }
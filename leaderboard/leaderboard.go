@@ -0,0 +1,274 @@
+package leaderboard
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"main/config"
+	"main/db"
+)
+
+// 순위표 종류
+type Category string
+
+const (
+	CategoryWins         Category = "wins"         // 1등 횟수
+	CategoryRating       Category = "rating"       // 현재 레이팅 (기간 안에 게임을 한 계정만)
+	CategoryBellAccuracy Category = "bellAccuracy" // 벨 정확도 (최소 벨 누른 횟수를 넘은 계정만)
+	CategoryFastestRing  Category = "fastestRing"  // 가장 빠른 올바른 벨 반응 시간 (밀리초, 낮을수록 높은 순위)
+)
+
+// 순위표 집계 기간 (지금부터 거슬러 올라가는 기간)
+type Period string
+
+const (
+	PeriodDaily   Period = "daily"   // 최근 24시간
+	PeriodWeekly  Period = "weekly"  // 최근 7일
+	PeriodAllTime Period = "allTime" // 전체 기간
+)
+
+// 순위표 에러
+var (
+	ErrUnknownCategory = errors.New("알 수 없는 순위표 종류입니다")
+	ErrUnknownPeriod   = errors.New("알 수 없는 순위표 기간입니다")
+	ErrNotReady        = errors.New("순위표를 아직 집계하지 못했습니다. 잠시 후 다시 시도하세요")
+)
+
+// 서버가 집계하는 순위표 종류와 기간
+var (
+	categories = []Category{CategoryWins, CategoryRating, CategoryBellAccuracy, CategoryFastestRing}
+	periods    = []Period{PeriodDaily, PeriodWeekly, PeriodAllTime}
+)
+
+// 순위표 항목
+type Entry struct {
+	Rank      int     `json:"rank"`      // 순위 (값이 같으면 같은 순위)
+	AccountID string  `json:"accountId"` // 계정 아이디
+	Nickname  string  `json:"nickname"`  // 계정 닉네임
+	Value     float64 `json:"value"`     // 순위표 종류에 따른 값 (1등 횟수, 레이팅, 정확도 0-1, 반응 시간 밀리초)
+	Matches   int     `json:"matches"`   // 기간 안에 플레이한 게임 수
+}
+
+// 순위표
+type Board struct {
+	Category  Category  `json:"category"`
+	Period    Period    `json:"period"`
+	Entries   []Entry   `json:"entries"`
+	UpdatedAt time.Time `json:"updatedAt"` // 마지막으로 집계한 시각
+}
+
+type boardKey struct {
+	category Category
+	period   Period
+}
+
+// 순위표 서비스 (DB에 저장된 게임 기록으로 순위표를 주기적으로 집계해 메모리에 보관)
+type Service struct {
+	cfg    config.LeaderboardConfig
+	mu     sync.RWMutex
+	boards map[boardKey]*Board
+}
+
+// 순위표 서비스 생성 (Start를 호출해야 집계 시작)
+func NewService(cfg config.LeaderboardConfig) *Service {
+	return &Service{
+		cfg:    cfg,
+		boards: make(map[boardKey]*Board),
+	}
+}
+
+// 바로 한 번 집계하고 설정된 간격마다 다시 집계
+func (s *Service) Start() {
+	go func() {
+		ticker := time.NewTicker(time.Duration(s.cfg.RefreshSeconds) * time.Second)
+		defer ticker.Stop()
+
+		for {
+			if err := s.Refresh(); err != nil {
+				log.Printf("순위표 집계 오류 - 이전 순위표 유지: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// 모든 순위표 다시 집계 (하나라도 실패하면 이전 순위표를 그대로 유지)
+func (s *Service) Refresh() error {
+	now := time.Now()
+	boards := make(map[boardKey]*Board, len(categories)*len(periods))
+
+	for _, category := range categories {
+		for _, period := range periods {
+			entries, err := s.query(category, periodStart(period, now))
+			if err != nil {
+				return fmt.Errorf("%s/%s: %w", category, period, err)
+			}
+			boards[boardKey{category, period}] = &Board{
+				Category:  category,
+				Period:    period,
+				Entries:   entries,
+				UpdatedAt: now,
+			}
+		}
+	}
+
+	s.mu.Lock()
+	s.boards = boards
+	s.mu.Unlock()
+	return nil
+}
+
+// 순위표 조회 (비어있으면 1등 횟수, 전체 기간)
+func (s *Service) Board(category Category, period Period) (*Board, error) {
+	if category == "" {
+		category = CategoryWins
+	}
+	if period == "" {
+		period = PeriodAllTime
+	}
+	if !knownCategory(category) {
+		return nil, ErrUnknownCategory
+	}
+	if !knownPeriod(period) {
+		return nil, ErrUnknownPeriod
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	board, exists := s.boards[boardKey{category, period}]
+	if !exists {
+		return nil, ErrNotReady
+	}
+	return board, nil
+}
+
+// HTTP 순위표 조회 (GET /leaderboard?category=wins&period=weekly)
+func (s *Service) HTTPHandler(c *gin.Context) {
+	board, err := s.Board(Category(c.Query("category")), Period(c.Query("period")))
+	switch {
+	case errors.Is(err, ErrNotReady):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+	case err != nil:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusOK, board)
+	}
+}
+
+// 집계 기간의 시작 시각 (전체 기간이면 zero time)
+func periodStart(period Period, now time.Time) time.Time {
+	switch period {
+	case PeriodDaily:
+		return now.Add(-24 * time.Hour)
+	case PeriodWeekly:
+		return now.Add(-7 * 24 * time.Hour)
+	}
+	return time.Time{}
+}
+
+// 순위표 종류별 집계 (since 이후에 끝난 게임만, 로그인한 계정만)
+func (s *Service) query(category Category, since time.Time) ([]Entry, error) {
+	var statement string
+	args := []interface{}{since, s.cfg.Size}
+
+	switch category {
+	case CategoryWins:
+		statement = `
+			SELECT mp.account_id, u.nickname, COUNT(*) FILTER (WHERE mp.rank = 1) AS value, COUNT(*) AS matches
+			FROM match_players mp
+			JOIN matches m ON m.id = mp.match_id
+			JOIN Users u ON u.id = mp.account_id
+			WHERE m.ended_at >= $1
+			GROUP BY mp.account_id, u.nickname
+			HAVING COUNT(*) FILTER (WHERE mp.rank = 1) > 0
+			ORDER BY value DESC, matches ASC, mp.account_id
+			LIMIT $2`
+	case CategoryRating:
+		statement = `
+			SELECT u.id, u.nickname, ROUND(u.rating)::float8 AS value, COUNT(*) AS matches
+			FROM match_players mp
+			JOIN matches m ON m.id = mp.match_id
+			JOIN Users u ON u.id = mp.account_id
+			WHERE m.ended_at >= $1 AND u.rated_games > 0
+			GROUP BY u.id, u.nickname, u.rating
+			ORDER BY value DESC, matches DESC, u.id
+			LIMIT $2`
+	case CategoryBellAccuracy:
+		statement = `
+			SELECT mp.account_id, u.nickname, SUM(mp.bell_hits)::float8 / SUM(mp.bell_hits + mp.bell_misses) AS value, COUNT(*) AS matches
+			FROM match_players mp
+			JOIN matches m ON m.id = mp.match_id
+			JOIN Users u ON u.id = mp.account_id
+			WHERE m.ended_at >= $1
+			GROUP BY mp.account_id, u.nickname
+			HAVING SUM(mp.bell_hits + mp.bell_misses) >= $3
+			ORDER BY value DESC, SUM(mp.bell_hits) DESC, mp.account_id
+			LIMIT $2`
+		args = append(args, s.cfg.MinBellPresses)
+	case CategoryFastestRing:
+		statement = `
+			SELECT mp.account_id, u.nickname, MIN(mp.fastest_ring_ms)::float8 AS value, COUNT(*) AS matches
+			FROM match_players mp
+			JOIN matches m ON m.id = mp.match_id
+			JOIN Users u ON u.id = mp.account_id
+			WHERE m.ended_at >= $1 AND mp.fastest_ring_ms IS NOT NULL
+			GROUP BY mp.account_id, u.nickname
+			ORDER BY value ASC, matches DESC, mp.account_id
+			LIMIT $2`
+	default:
+		return nil, ErrUnknownCategory
+	}
+
+	rows, err := db.DB.Query(statement, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []Entry{}
+	for rows.Next() {
+		var entry Entry
+		if err := rows.Scan(&entry.AccountID, &entry.Nickname, &entry.Value, &entry.Matches); err != nil {
+			return nil, err
+		}
+
+		// 값이 같으면 같은 순위 (1, 1, 3, ...)
+		entry.Rank = len(entries) + 1
+		if previous := len(entries) - 1; previous >= 0 && entries[previous].Value == entry.Value {
+			entry.Rank = entries[previous].Rank
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// 서버가 집계하는 순위표 종류인지 확인
+func knownCategory(category Category) bool {
+	for _, known := range categories {
+		if known == category {
+			return true
+		}
+	}
+	return false
+}
+
+// 서버가 집계하는 기간인지 확인
+func knownPeriod(period Period) bool {
+	for _, known := range periods {
+		if known == period {
+			return true
+		}
+	}
+	return false
+}
@@ -1,52 +1,97 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"log"
+	"os"
+	"time"
 
-	// "main/auth"
-	// "main/db"
-
+	"main/auth"
+	"main/config"
 	"main/db"
+	"main/leaderboard"
 	"main/socket"
+	"main/utils"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 )
 
-const (
-	// 서버 포트 설정
-	DefaultPort = ":8081"
-)
-
 func main() {
-	// ✅ .env 로드 (Google 로그인 비활성화)
-	err := godotenv.Load()
+	// ✅ .env 로드 (없으면 이미 설정된 환경변수만 사용)
+	if err := godotenv.Load(); err != nil {
+		log.Printf(".env 파일 로드 실패 - 환경변수만 사용: %v", err)
+	}
+
+	// ✅ 설정 파일 경로 (-config 플래그 > HALLIGALLI_CONFIG 환경변수 > config.yaml)
+	defaultPath := os.Getenv("HALLIGALLI_CONFIG")
+	if defaultPath == "" {
+		defaultPath = config.DefaultConfigPath
+	}
+	configPath := flag.String("config", defaultPath, "설정 파일 경로 (YAML 또는 JSON)")
+	flag.Parse()
+
+	// ✅ 설정 로드 및 검증
+	serverConfig, err := config.Load(*configPath)
 	if err != nil {
-		log.Fatal(".env 파일 로드 실패:", err)
+		log.Fatal("설정 로드 실패:", err)
 	}
-	// fmt.Println("✅ GOOGLE_CLIENT_ID:", os.Getenv("GOOGLE_CLIENT_ID"))
-	// fmt.Println("✅ GOOGLE_CLIENT_SECRET:", os.Getenv("GOOGLE_CLIENT_SECRET"))
+	config.SetDefaultConfig(serverConfig.Game)
+	config.WatchReload(*configPath, serverConfig)
 
-	// ✅ 설정 (Google 로그인 비활성화)
-	// auth.SetupGoogleOAuth()
-	db.Init()
+	// ✅ 설정
+	db.Init(serverConfig.DatabaseDSN)
+	auth.SetupSessions(serverConfig.Session.Secret, time.Duration(serverConfig.Session.TTLHours)*time.Hour)
 
-	r := gin.Default()
+	// 요청 로그에는 쿼리 문자열(?token= 세션 토큰, OAuth code)을 남기지 않음
+	r := gin.New()
+	r.Use(gin.LoggerWithConfig(gin.LoggerConfig{Formatter: pathOnlyLogFormatter}), gin.Recovery())
 
-	// ✅ Google OAuth 라우터 (비활성화)
-	// r.GET("/google/auth/login", auth.GoogleLoginHandler)
-	// r.GET("/google/oauth2", auth.GoogleCallbackHandler)
+	// ✅ Google OAuth 라우터 (클라이언트 ID가 설정된 경우에만)
+	if serverConfig.OAuth.GoogleEnabled() {
+		auth.SetupGoogleOAuth(serverConfig.OAuth)
+		r.GET("/google/auth/login", auth.GoogleLoginHandler)
+		r.GET("/google/oauth2", auth.GoogleCallbackHandler)
+	} else {
+		log.Printf("Google 로그인 비활성화 - oauth.googleClientId가 설정되지 않음")
+	}
 
 	// ✅ WebSocket 핸들러
-	handler := socket.NewHandler()
+	handler := socket.NewHandler(serverConfig.AllowedOrigins)
+	handler.SetProfanityFilter(utils.NewWordListFilter(serverConfig.BannedWords))
+	handler.SetLatencyConfig(serverConfig.Latency)
+	handler.SetBotProfiles(serverConfig.Bots)
 	go handler.Run()
 	r.GET("/ws", func(c *gin.Context) {
 		handler.HandleWebSocket(c.Writer, c.Request)
 	})
 
+	// ✅ 순위표 (주기적으로 집계해 메모리에 보관)
+	board := leaderboard.NewService(serverConfig.Leaderboard)
+	board.Start()
+	handler.SetLeaderboard(board)
+	r.GET("/leaderboard", board.HTTPHandler)
+
 	// ✅ 서버 실행
-	log.Printf("서버 시작: %s 포트", DefaultPort)
-	if err := r.Run(DefaultPort); err != nil {
+	log.Printf("서버 시작: %s 포트", serverConfig.ListenAddr)
+	if err := r.Run(serverConfig.ListenAddr); err != nil {
 		log.Fatal("서버 실행 실패:", err)
 	}
 }
+
+// 요청 로그 형식 (gin 기본 형식에서 쿼리 문자열을 뺀 경로만 출력)
+func pathOnlyLogFormatter(param gin.LogFormatterParams) string {
+	if param.Latency > time.Minute {
+		param.Latency = param.Latency.Truncate(time.Second)
+	}
+	return fmt.Sprintf("[GIN] %v | %3d | %13v | %15s | %-7s %#v\n%s",
+		param.TimeStamp.Format("2006/01/02 - 15:04:05"),
+		param.StatusCode,
+		param.Latency,
+		param.ClientIP,
+		param.Method,
+		param.Request.URL.Path,
+		param.ErrorMessage,
+	)
+}
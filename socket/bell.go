@@ -0,0 +1,95 @@
+package socket
+
+import (
+	"log"
+	"sort"
+	"time"
+
+	"main/config"
+	"main/game"
+)
+
+// 판정을 기다리는 벨 누름
+type bellPress struct {
+	client     *Client // 누른 클라이언트 (봇이면 nil)
+	playerID   string
+	username   string
+	pressedAt  time.Time // 서버가 받은 시각에서 지연 보정을 뺀 누른 시각 (누른 순서 판정에만 사용)
+	receivedAt time.Time // 서버가 받은 시각 (반응 시간 기록에 사용)
+}
+
+// 벨 누른 시각에서 빼 줄 지연 시간
+// 누른 뒤 서버에 도착하기까지는 편도 지연만 걸리므로 왕복 지연 시간의 절반 (최대 MaxBellLatencyCompensationMs)
+func (c *Client) latencyCompensation() time.Duration {
+	compensation := c.roundTripTime() / 2
+	if limit := config.MaxBellLatencyCompensationMs * time.Millisecond; compensation > limit {
+		return limit
+	}
+	return compensation
+}
+
+// 벨 누름을 판정 구간에 추가 (첫 누름이면 판정 구간을 시작)
+// 판정 구간이 끝나면 가장 먼저 누른 유효한 누름 하나만 판정하고 나머지는 무시
+func (h *Handler) submitBellPress(room *Room, press *bellPress) error {
+	if !room.game.IsGameStarted() {
+		return game.ErrGameNotStarted
+	}
+	if !room.game.IsCardGameStarted() {
+		return game.ErrCardGameNotStarted
+	}
+	if !room.game.HasPlayer(press.playerID) {
+		return game.ErrPlayerNotSeated
+	}
+
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	if room.game.IsBellRung() {
+		log.Printf("플레이어 벨 누름 무시: %s (%s) - 이미 벨이 눌린 상태", press.playerID, press.username)
+		return nil
+	}
+
+	// 판정 구간 안에서는 플레이어마다 첫 누름만 인정
+	for _, pending := range room.bellPresses {
+		if pending.playerID == press.playerID {
+			return nil
+		}
+	}
+	room.bellPresses = append(room.bellPresses, press)
+
+	if room.bellTimer == nil {
+		room.bellTimer = time.AfterFunc(config.BellAdjudicationWindowMs*time.Millisecond, func() {
+			h.resolveBellPresses(room)
+		})
+	}
+	return nil
+}
+
+// 판정 구간이 끝나면 가장 먼저 누른 벨 누름부터 판정 (판정할 수 없는 누름은 건너뜀)
+func (h *Handler) resolveBellPresses(room *Room) {
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	presses := room.bellPresses
+	room.bellPresses = nil
+	room.bellTimer = nil
+
+	sort.SliceStable(presses, func(i, j int) bool {
+		return presses[i].pressedAt.Before(presses[j].pressedAt)
+	})
+
+	for i, press := range presses {
+		err := h.ringBellLocked(room, press)
+		if err == nil {
+			if late := len(presses) - i - 1; late > 0 {
+				log.Printf("벨 판정 완료 - 방: %s, %s (%s) 가장 빠름, 늦게 누른 %d명 무시", room.id, press.playerID, press.username, late)
+			}
+			return
+		}
+
+		log.Printf("벨 누르기 실패: %s (%s) - %v", press.playerID, press.username, err)
+		if press.client != nil {
+			h.sendErrorFor(press.client, RequestRingBell, err)
+		}
+	}
+}
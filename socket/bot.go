@@ -0,0 +1,108 @@
+package socket
+
+import (
+	"errors"
+	"log"
+	"math/rand"
+	"time"
+
+	"main/config"
+	"main/game"
+)
+
+// 봇 관련 에러
+var (
+	ErrUnknownBotDifficulty = errors.New("알 수 없는 봇 난이도입니다")
+	ErrBotNotFound          = errors.New("방에 봇이 없습니다")
+)
+
+// 봇 플레이어 (서버 안에서 동작하며 빈 좌석을 채움)
+type botPlayer struct {
+	id         string
+	username   string
+	difficulty string
+	profile    config.BotProfile
+}
+
+// 새로운 봇 생성 (설정에 없는 난이도면 false)
+func newBotPlayer(difficulty string, profiles map[string]config.BotProfile) (*botPlayer, bool) {
+	if difficulty == "" {
+		difficulty = config.DefaultBotDifficulty
+	}
+	profile, exists := profiles[difficulty]
+	if !exists {
+		return nil, false
+	}
+
+	return &botPlayer{
+		id:         "bot-" + generateRandomCode(8),
+		username:   "Bot" + generateRandomNumber(4),
+		difficulty: difficulty,
+		profile:    profile,
+	}, true
+}
+
+// 반응 시간 계산 (정규분포에서 뽑은 뒤 최소 반응 시간으로 제한)
+func (b *botPlayer) reactionDelay() time.Duration {
+	ms := float64(b.profile.ReactionMeanMs) + rand.NormFloat64()*float64(b.profile.ReactionStdDevMs)
+	if ms < float64(b.profile.MinReactionMs) {
+		ms = float64(b.profile.MinReactionMs)
+	}
+	return time.Duration(ms) * time.Millisecond
+}
+
+// 공개된 카드에 반응해 벨을 칠지 결정
+func (b *botPlayer) wantsToRing(isBellRingingTime bool) bool {
+	if isBellRingingTime {
+		return rand.Float64() >= b.profile.MissRate
+	}
+	return rand.Float64() < b.profile.FalseRingRate
+}
+
+// 방에 봇 추가 (게임 시작 전에만 가능)
+func (h *Handler) addBot(room *Room, difficulty string) (*botPlayer, error) {
+	bot, ok := newBotPlayer(difficulty, h.botProfiles)
+	if !ok {
+		return nil, ErrUnknownBotDifficulty
+	}
+
+	if _, err := room.game.AddBot(bot.id, bot.username); err != nil {
+		return nil, err
+	}
+	room.addBot(bot)
+
+	log.Printf("봇 추가: %s (%s, 난이도: %s) - 방: %s", bot.id, bot.username, bot.difficulty, room.id)
+	return bot, nil
+}
+
+// 게임 시작 직후 모든 봇을 게임 화면 준비 완료 처리
+func (h *Handler) markBotsReady(room *Room) {
+	for _, bot := range room.botPlayers() {
+		if err := h.markReady(room, bot.id, bot.username); err != nil {
+			log.Printf("봇 준비 실패: %s (%s) - %v", bot.id, bot.username, err)
+		}
+	}
+}
+
+// 카드가 공개될 때마다 봇들의 벨 누르기 예약 (뮤텍스가 이미 잠겨있는 상태에서 호출)
+func (h *Handler) scheduleBotReactions(room *Room, opened *game.OpenCardResult) {
+	for _, bot := range room.botPlayers() {
+		if !bot.wantsToRing(opened.BellRingingTime) {
+			continue
+		}
+
+		bot := bot
+		revealCount := opened.RevealCount
+		time.AfterFunc(bot.reactionDelay(), func() {
+			// 반응하기 전에 다음 카드가 공개되었거나 게임이 끝났으면 무시
+			if !room.game.IsGameStarted() || room.game.RevealCount() != revealCount || !room.game.HasPlayer(bot.id) {
+				return
+			}
+			now := time.Now()
+			press := &bellPress{playerID: bot.id, username: bot.username, pressedAt: now, receivedAt: now}
+			if err := h.submitBellPress(room, press); err != nil {
+				log.Printf("봇 벨 누르기 실패: %s (%s) - %v", bot.id, bot.username, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package socket
+
+import (
+	"encoding/json"
+	"log"
+	"reflect"
+
+	"github.com/gorilla/websocket"
+	"github.com/ugorji/go/codec"
+)
+
+// 패킷 인코딩 방식 (WebSocket 서브프로토콜로 연결마다 정함)
+type Encoding int
+
+const (
+	EncodingJSON    Encoding = iota // JSON 텍스트 메시지 (기본값)
+	EncodingMsgpack                 // MessagePack 바이너리 메시지
+)
+
+// 인코딩 방식을 고르는 WebSocket 서브프로토콜 이름
+const (
+	SubprotocolJSON    = "halligalli.json"
+	SubprotocolMsgpack = "halligalli.msgpack"
+)
+
+// 서버가 받아주는 서브프로토콜 (클라이언트가 Sec-WebSocket-Protocol에 적은 순서대로 고름)
+var supportedSubprotocols = []string{SubprotocolMsgpack, SubprotocolJSON}
+
+// MessagePack 인코딩 설정 (구조체 필드 이름은 json 태그를 따름)
+var msgpackHandle = func() *codec.MsgpackHandle {
+	handle := &codec.MsgpackHandle{WriteExt: true}
+	handle.RawToString = true
+	handle.MapType = reflect.TypeOf(map[string]interface{}(nil))
+	return handle
+}()
+
+// 서브프로토콜 이름에 해당하는 인코딩 방식 (서브프로토콜이 없으면 JSON)
+func encodingForSubprotocol(subprotocol string) Encoding {
+	if subprotocol == SubprotocolMsgpack {
+		return EncodingMsgpack
+	}
+	return EncodingJSON
+}
+
+// 인코딩 방식 이름
+func (e Encoding) String() string {
+	if e == EncodingMsgpack {
+		return "msgpack"
+	}
+	return "json"
+}
+
+// 인코딩 방식에 맞는 WebSocket 메시지 타입
+func (e Encoding) messageType() int {
+	if e == EncodingMsgpack {
+		return websocket.BinaryMessage
+	}
+	return websocket.TextMessage
+}
+
+// 받은 요청 메시지를 JSON으로 변환 (요청 패킷 검증과 요청 데이터 디코딩은 JSON 기준)
+func (e Encoding) requestToJSON(message []byte) ([]byte, error) {
+	if e != EncodingMsgpack {
+		return message, nil
+	}
+
+	var request interface{}
+	if err := codec.NewDecoderBytes(message, msgpackHandle).Decode(&request); err != nil {
+		return nil, err
+	}
+	return json.Marshal(request)
+}
+
+// 인코딩 방식별로 한 번씩만 마샬링해 여러 클라이언트가 나눠 쓰는 전송 프레임
+// 한 번의 전송/브로드캐스트 안에서만 쓰고 고루틴 사이에 공유하지 않음
+type packetFrame struct {
+	message interface{}
+	encoded map[Encoding][]byte
+}
+
+func newPacketFrame(message interface{}) *packetFrame {
+	return &packetFrame{message: message, encoded: make(map[Encoding][]byte, 2)}
+}
+
+// 인코딩 방식에 맞게 마샬링된 패킷 (처음 요청될 때 한 번만 마샬링)
+func (f *packetFrame) bytes(encoding Encoding) ([]byte, error) {
+	if data, ok := f.encoded[encoding]; ok {
+		return data, nil
+	}
+
+	var data []byte
+	var err error
+	if encoding == EncodingMsgpack {
+		data, err = f.marshalMsgpack()
+	} else {
+		data, err = f.marshalJSON()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	f.encoded[encoding] = data
+	return data, nil
+}
+
+// JSON 마샬링 (Packet 타입인 경우 ToJSONWithLog 사용)
+func (f *packetFrame) marshalJSON() ([]byte, error) {
+	if packet, ok := f.message.(*ResponsePacket); ok {
+		return packet.ToJSONWithLog()
+	}
+
+	// 기존 호환성을 위한 fallback
+	data, err := json.Marshal(f.message)
+	if err != nil {
+		log.Printf("메시지 마샬링 오류: %v", err)
+		return nil, err
+	}
+	return data, nil
+}
+
+// MessagePack 마샬링 (바이너리라서 로그에는 signal과 크기만 남김)
+func (f *packetFrame) marshalMsgpack() ([]byte, error) {
+	var data []byte
+	if err := codec.NewEncoderBytes(&data, msgpackHandle).Encode(f.message); err != nil {
+		log.Printf("MessagePack 마샬링 오류: %v", err)
+		return nil, err
+	}
+
+	if packet, ok := f.message.(*ResponsePacket); ok {
+		log.Printf("전송 패킷 (msgpack, %d바이트): signal %d", len(data), packet.Signal)
+	}
+	return data, nil
+}
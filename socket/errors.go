@@ -0,0 +1,115 @@
+package socket
+
+import (
+	"errors"
+
+	"main/auth"
+	"main/game"
+	"main/leaderboard"
+)
+
+// 에러 코드 (에러 응답 data의 errorCode, 클라이언트는 이 값으로 에러를 구분하고 안내 문구를 현지화)
+type ErrorCode int
+
+const (
+	// 요청 형식 (1xxx)
+	ErrCodeInvalidPacket      ErrorCode = 1000 // 패킷 형식 오류
+	ErrCodeUnknownSignal      ErrorCode = 1001 // 등록되지 않은 signal
+	ErrCodeInvalidPayload     ErrorCode = 1002 // 요청 데이터 검증 실패 (field, reason 포함)
+	ErrCodeRateLimited        ErrorCode = 1003 // 요청 횟수 제한 초과
+	ErrCodeUpgradeRequired    ErrorCode = 1004 // 클라이언트 프로토콜 버전이 너무 낮음 (업데이트 필요)
+	ErrCodeHighLatency        ErrorCode = 1005 // 왕복 지연 시간이 계속 기준을 넘어 연결 종료
+	ErrCodeCapabilityRequired ErrorCode = 1006 // 핸드셰이크에서 협상하지 않은 부가 기능의 요청
+
+	// 계정 (2xxx)
+	ErrCodeLoginRequired     ErrorCode = 2000 // 로그인 필요
+	ErrCodeAlreadyLoggedIn   ErrorCode = 2001 // 이미 로그인한 상태
+	ErrCodeAuthFailed        ErrorCode = 2002 // 존재하지 않는 ID 또는 잘못된 비밀번호
+	ErrCodeInvalidSession    ErrorCode = 2003 // 유효하지 않거나 만료/폐기된 세션 토큰
+	ErrCodeDuplicateID       ErrorCode = 2004 // 이미 존재하는 ID
+	ErrCodeDuplicateNickname ErrorCode = 2005 // 다른 계정이 쓰는 닉네임
+	ErrCodeBannedNickname    ErrorCode = 2006 // 금칙어가 포함된 닉네임
+	ErrCodeSameNickname      ErrorCode = 2007 // 현재와 같은 닉네임
+	ErrCodeAccountNotFound   ErrorCode = 2008 // 존재하지 않는 계정
+
+	// 방 (3xxx)
+	ErrCodeAlreadyInRoom     ErrorCode = 3000 // 이미 방에 참여/관전 중
+	ErrCodeNotInRoom         ErrorCode = 3001 // 방에 참여하지 않은 상태
+	ErrCodeRoomNotFound      ErrorCode = 3002 // 존재하지 않는 방
+	ErrCodeRoomFull          ErrorCode = 3003 // 방이 꽉 참
+	ErrCodeSpectatorsFull    ErrorCode = 3004 // 관전자 수가 가득 참
+	ErrCodeRoomCodeRequired  ErrorCode = 3005 // 비공개 방은 참여 코드 필요 (또는 방 ID/코드 누락)
+	ErrCodeNotHost           ErrorCode = 3006 // 방장만 할 수 있는 요청
+	ErrCodeStartPolicy       ErrorCode = 3007 // 방의 게임 시작 방식과 맞지 않는 요청, 알 수 없는 시작 방식
+	ErrCodeInvalidGameConfig ErrorCode = 3008 // 게임 설정 값 오류
+	ErrCodeBotNotFound       ErrorCode = 3009 // 방에 봇이 없음
+	ErrCodeUnknownDifficulty ErrorCode = 3010 // 알 수 없는 봇 난이도
+	ErrCodeInRoom            ErrorCode = 3011 // 방에 참여한 상태에서는 할 수 없는 요청
+	ErrCodeAlreadyInQueue    ErrorCode = 3012 // 이미 매칭을 기다리는 중
+	ErrCodeNotInQueue        ErrorCode = 3013 // 매칭을 기다리는 중이 아님
+
+	// 게임 (4xxx)
+	ErrCodeGameStarted      ErrorCode = 4000 // 게임이 이미 시작됨
+	ErrCodeGameNotStarted   ErrorCode = 4001 // 게임(또는 카드 게임)이 시작되지 않음
+	ErrCodeNotEnoughPlayers ErrorCode = 4002 // 게임을 시작할 플레이어 부족
+	ErrCodePlayerNotSeated  ErrorCode = 4003 // 좌석이 없는 플레이어
+	ErrCodeReconnectFailed  ErrorCode = 4004 // 재접속 토큰이 없거나 만료/사용 중, 재접속할 게임 없음
+
+	// 서버 (5xxx)
+	ErrCodeServerError ErrorCode = 5000 // DB 오류 등 서버 내부 오류
+
+	// 순위표 (6xxx)
+	ErrCodeUnknownLeaderboard  ErrorCode = 6000 // 알 수 없는 순위표 종류 또는 기간
+	ErrCodeLeaderboardNotReady ErrorCode = 6001 // 순위표를 아직 집계하지 못함
+)
+
+// 계정 에러
+var (
+	ErrDuplicateID       = errors.New("이미 존재하는 ID입니다")
+	ErrDuplicateNickname = errors.New("이미 사용 중인 Nickname입니다")
+	ErrBannedNickname    = errors.New("사용할 수 없는 단어가 포함된 Nickname입니다")
+	ErrAccountNotFound   = errors.New("존재하지 않는 계정입니다")
+)
+
+// 에러 값에 해당하는 에러 코드 (알 수 없는 에러는 서버 오류)
+func errorCodeFor(err error) ErrorCode {
+	switch {
+	case errors.Is(err, game.ErrRoomFull):
+		return ErrCodeRoomFull
+	case errors.Is(err, game.ErrPlayerExists):
+		return ErrCodeAlreadyInRoom
+	case errors.Is(err, game.ErrGameStarted):
+		return ErrCodeGameStarted
+	case errors.Is(err, game.ErrGameNotStarted), errors.Is(err, game.ErrCardGameNotStarted), errors.Is(err, game.ErrNoCardsLeft):
+		return ErrCodeGameNotStarted
+	case errors.Is(err, game.ErrNotEnoughPlayers):
+		return ErrCodeNotEnoughPlayers
+	case errors.Is(err, game.ErrPlayerNotSeated):
+		return ErrCodePlayerNotSeated
+	case errors.Is(err, ErrAlreadyInQueue):
+		return ErrCodeAlreadyInQueue
+	case errors.Is(err, ErrNotInQueue):
+		return ErrCodeNotInQueue
+	case errors.Is(err, ErrBotNotFound):
+		return ErrCodeBotNotFound
+	case errors.Is(err, ErrUnknownBotDifficulty):
+		return ErrCodeUnknownDifficulty
+	case errors.Is(err, ErrResumeSessionNotFound), errors.Is(err, ErrResumeSessionInUse), errors.Is(err, ErrResumeSessionExpired):
+		return ErrCodeReconnectFailed
+	case errors.Is(err, auth.ErrInvalidSessionToken), errors.Is(err, auth.ErrSessionExpired), errors.Is(err, auth.ErrSessionRevoked):
+		return ErrCodeInvalidSession
+	case errors.Is(err, ErrDuplicateID):
+		return ErrCodeDuplicateID
+	case errors.Is(err, ErrDuplicateNickname):
+		return ErrCodeDuplicateNickname
+	case errors.Is(err, ErrBannedNickname):
+		return ErrCodeBannedNickname
+	case errors.Is(err, ErrAccountNotFound):
+		return ErrCodeAccountNotFound
+	case errors.Is(err, leaderboard.ErrUnknownCategory), errors.Is(err, leaderboard.ErrUnknownPeriod):
+		return ErrCodeUnknownLeaderboard
+	case errors.Is(err, leaderboard.ErrNotReady):
+		return ErrCodeLeaderboardNotReady
+	}
+	return ErrCodeServerError
+}
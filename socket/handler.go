@@ -3,27 +3,43 @@ package socket
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
-	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"math/rand"
 
+	"main/auth"
 	"main/config"
 	"main/db"
+	"main/game"
+	"main/leaderboard"
 	"main/utils"
 
 	"github.com/gorilla/websocket"
 )
 
-// WebSocket 업그레이더 설정
-var upgrader = websocket.Upgrader{
-	CheckOrigin: func(r *http.Request) bool {
-		return true // CORS 허용 (개발용)
-	},
+// WebSocket 업그레이더 생성 (허용 Origin이 비어있거나 "*"를 포함하면 모두 허용)
+func newUpgrader(allowedOrigins []string) websocket.Upgrader {
+	allowed := make(map[string]bool, len(allowedOrigins))
+	for _, origin := range allowedOrigins {
+		allowed[origin] = true
+	}
+
+	return websocket.Upgrader{
+		Subprotocols: supportedSubprotocols,
+		CheckOrigin: func(r *http.Request) bool {
+			if len(allowed) == 0 || allowed["*"] {
+				return true // CORS 허용 (개발용)
+			}
+			return allowed[r.Header.Get("Origin")]
+		},
+	}
 }
 
 // rand 시드 초기화
@@ -31,46 +47,6 @@ func init() {
 	rand.Seed(time.Now().UnixNano())
 }
 
-// 방 정보 구조체
-type Room struct {
-	mu            sync.RWMutex
-	players       map[string]*Player
-	maxPlayers    int
-	isGameStarted bool
-	playerCards   []int           // 각 플레이어별 카드 개수 (인덱스 기반)
-	readyPlayers  map[string]bool // 준비 완료한 플레이어들
-	// 플레이어 인덱스 매핑 (게임 시작 시 설정)
-	playerIndexes map[string]int // 플레이어 ID -> 인덱스 매핑
-	// 카드 공개 관련 상태
-	isCardGameStarted  bool        // 카드 게임이 시작되었는지
-	currentPlayerIndex int         // 현재 카드를 낼 플레이어 인덱스
-	cardTimer          *time.Timer // 카드 공개 타이머
-	// 각 플레이어의 공개된 카드 정보 (인덱스 기반)
-	publicFruitIndexes []int // 각 플레이어의 공개된 카드 과일 인덱스
-	publicFruitCounts  []int // 각 플레이어의 공개된 카드 과일 개수
-	openCards          []int // 각 플레이어가 공개한 카드 개수
-	// 벨 누르기 관련 상태
-	bellRung bool // 벨이 눌렸는지 여부 (새로운 카드 공개 전까지 유지)
-	// 게임 제한시간 관련 상태
-	gameTimer     *time.Timer // 게임 제한시간 타이머
-	isTimeExpired bool        // 시간제한이 끝났는지 여부
-	// 감정표현 관련 상태
-	lastEmotionTimes map[string]time.Time // 각 클라이언트별 마지막 감정표현 시간
-}
-
-// 플레이어 정보 구조체
-type Player struct {
-	ID       string `json:"id"`
-	Username string `json:"username"`
-}
-
-// 전역 방 인스턴스
-var GlobalRoom = &Room{
-	players:          make(map[string]*Player),
-	maxPlayers:       config.MaxPlayers, // 설정에서 가져온 최대 플레이어 수
-	lastEmotionTimes: make(map[string]time.Time),
-}
-
 // 클라이언트 구조체 (소켓 연결 정보)
 type Client struct {
 	ID       string          `json:"id"`
@@ -81,6 +57,40 @@ type Client struct {
 	// 방 참여 상태
 	IsInRoom bool   `json:"isInRoom"`
 	Username string `json:"username"`
+	Room     *Room  `json:"-"` // 참여 중인 방 (관전 중인 방 포함)
+	// 관전 상태 (관전자는 IsInRoom이 false이고 플레이어로 집계되지 않음)
+	IsSpectating bool `json:"isSpectating"`
+	// 로그인한 계정 (로그인하지 않았으면 빈 문자열)
+	AccountID string `json:"accountId"`
+	Nickname  string `json:"nickname"`
+	// 로그인 시 발급받았거나 복원에 사용한 세션 토큰 (로그아웃 시 폐기)
+	sessionToken string
+	// 재접속 토큰 (연결 시 발급, 게임 중 연결이 끊기면 좌석을 되찾는 데 사용)
+	resumeToken string
+	// 시그널별 요청 횟수 제한 구간
+	rateWindows map[int]*rateWindow
+	// 핸드셰이크로 정한 프로토콜 버전과 부가 기능 (핸드셰이크 전에는 0과 nil)
+	protocolVersion int
+	capabilities    map[string]bool
+	// 연결 시 서브프로토콜로 정한 패킷 인코딩 방식
+	encoding Encoding
+	// 전송 채널 상태 (Send는 Run의 연결 해제 처리에서만 한 번 닫고, 닫힌 뒤에는 보내지 않음)
+	sendMu     sync.Mutex
+	sendClosed bool
+	// 서버가 연결을 끊기로 한 상태 (readPump가 더 이상 요청을 처리하지 않음)
+	closing atomic.Bool
+	// 서버가 연결을 끊기로 하면 닫히는 채널 (writePump가 남은 패킷을 보낸 뒤 연결을 닫음)
+	done chan struct{}
+	// 마지막 메시지를 받은 시각 (읽기 고루틴에서만 사용)
+	receivedAt time.Time
+	// 퐁을 기다리는 핑을 보낸 시각 (기다리는 핑이 없으면 0, 이 시각을 돌려준 퐁만 측정에 사용)
+	pingSentAt time.Time
+	// 핑/퐁으로 측정한 왕복 지연 시간과 흔들림 (측정 전에는 0)
+	rtt    time.Duration
+	jitter time.Duration
+	// 지연 시간 경고를 보낸 상태인지와 연속으로 연결 종료 기준을 넘은 횟수
+	latencyWarned  bool
+	latencyStrikes int
 }
 
 // 핸들러 구조체
@@ -90,21 +100,66 @@ type Handler struct {
 	register   chan *Client
 	unregister chan *Client
 	mu         sync.RWMutex
+	rooms      *RoomManager
+	sessions   *resumeSessionStore
+	queue      *matchQueue
+	upgrader   websocket.Upgrader
+	// 닉네임 금칙어 필터 (SetProfanityFilter로 교체)
+	profanityFilter utils.ProfanityFilter
+	// 지연 시간 측정 설정 (SetLatencyConfig로 교체)
+	latency config.LatencyConfig
+	// 난이도별 봇 설정 (SetBotProfiles로 교체)
+	botProfiles map[string]config.BotProfile
+	// 순위표 서비스 (SetLeaderboard로 설정, 없으면 순위표 조회 불가)
+	leaderboard *leaderboard.Service
+	// 시그널별 요청 처리기 (registerSignalHandlers에서 등록)
+	signalHandlers map[int]*SignalHandler
 }
 
 // 새로운 핸들러 생성
-func NewHandler() *Handler {
-	return &Handler{
+func NewHandler(allowedOrigins []string) *Handler {
+	h := &Handler{
+		upgrader:   newUpgrader(allowedOrigins),
 		clients:    make(map[*Client]bool),
 		broadcast:  make(chan []byte),
 		register:   make(chan *Client),
 		unregister: make(chan *Client),
+		rooms:      NewRoomManager(),
+		sessions:   newResumeSessionStore(),
+		queue:      newMatchQueue(),
+		latency:    config.DefaultLatencyConfig(),
+
+		botProfiles:     config.DefaultBotProfiles(),
+		profanityFilter: utils.NewWordListFilter(nil),
+		signalHandlers:  make(map[int]*SignalHandler),
 	}
+	h.registerSignalHandlers()
+	return h
+}
+
+// 닉네임 금칙어 필터 교체 (서버 시작 시 설정)
+func (h *Handler) SetProfanityFilter(filter utils.ProfanityFilter) {
+	h.profanityFilter = filter
+}
+
+// 지연 시간 측정 설정 교체 (서버 시작 시 Run 전에 설정)
+func (h *Handler) SetLatencyConfig(cfg config.LatencyConfig) {
+	h.latency = cfg
+}
+
+// 난이도별 봇 설정 교체 (서버 시작 시 Run 전에 설정)
+func (h *Handler) SetBotProfiles(profiles map[string]config.BotProfile) {
+	h.botProfiles = profiles
+}
+
+// 순위표 서비스 설정 (서버 시작 시 설정)
+func (h *Handler) SetLeaderboard(service *leaderboard.Service) {
+	h.leaderboard = service
 }
 
 // WebSocket 연결 핸들러
 func (h *Handler) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
-	conn, err := upgrader.Upgrade(w, r, nil)
+	conn, err := h.upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		log.Printf("WebSocket 업그레이드 실패: %v", err)
 		return
@@ -114,19 +169,41 @@ func (h *Handler) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 		ID:       generateClientID(),
 		Conn:     conn,
 		Send:     make(chan []byte, 256),
+		done:     make(chan struct{}),
 		LastPing: time.Now(),
+		encoding: encodingForSubprotocol(conn.Subprotocol()),
 	}
 
+	// 재접속 토큰 발급
+	client.resumeToken = h.sessions.issue(client.ID)
+
 	// 클라이언트 등록
 	h.register <- client
 
-	// 연결 성공 메시지 전송
-	response := NewSuccessResponse(ResponsePong, map[string]interface{}{
-		"clientId": client.ID,
-		"message":  "연결이 성공적으로 설정되었습니다.",
-	})
+	// 쿼리 파라미터로 받은 세션 토큰이 있으면 로그인 상태 복원
+	var sessionErr error
+	if token := r.URL.Query().Get("token"); token != "" {
+		_, sessionErr = h.restoreSession(client, token)
+	}
+
+	// 연결 성공 메시지 전송 (로그인 상태가 복원되었으면 계정 정보 포함)
+	pongData := map[string]interface{}{
+		"clientId":        client.ID,
+		"resumeToken":     client.resumeToken,
+		"protocolVersion": ProtocolVersion,
+		"message":         "연결이 성공적으로 설정되었습니다.",
+	}
+	if client.AccountID != "" {
+		pongData["accountId"] = client.AccountID
+		pongData["nickname"] = client.Nickname
+	}
+	response := NewSuccessResponse(ResponsePong, pongData)
 	h.sendToClient(client, response)
 
+	if sessionErr != nil {
+		h.sendErrorFor(client, RequestSessionLogin, sessionErr)
+	}
+
 	// 클라이언트 메시지 처리 고루틴 시작
 	go h.readPump(client)
 	go h.writePump(client)
@@ -134,15 +211,16 @@ func (h *Handler) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 
 // 클라이언트로부터 메시지 읽기
 func (h *Handler) readPump(client *Client) {
+	// 연결은 Send 채널이 닫힌 뒤 writePump가 남은 패킷을 보내고 닫음
 	defer func() {
 		h.unregister <- client
-		client.Conn.Close()
 	}()
 
 	client.Conn.SetReadLimit(512) // 메시지 크기 제한
 	client.Conn.SetReadDeadline(time.Now().Add(60 * time.Second))
-	client.Conn.SetPongHandler(func(string) error {
+	client.Conn.SetPongHandler(func(appData string) error {
 		client.Conn.SetReadDeadline(time.Now().Add(60 * time.Second))
+		h.handlePong(client, appData)
 		return nil
 	})
 
@@ -155,6 +233,14 @@ func (h *Handler) readPump(client *Client) {
 			break
 		}
 
+		// 서버가 연결을 끊기로 했으면 남은 요청은 처리하지 않음
+		if client.closing.Load() {
+			break
+		}
+
+		// 받은 시각 기록 (벨 누르기 판정에 사용)
+		client.receivedAt = time.Now()
+
 		// 메시지 처리
 		h.handleMessage(client, message)
 	}
@@ -162,12 +248,18 @@ func (h *Handler) readPump(client *Client) {
 
 // 클라이언트에게 메시지 쓰기
 func (h *Handler) writePump(client *Client) {
-	ticker := time.NewTicker(54 * time.Second)
+	ticker := time.NewTicker(time.Duration(h.latency.PingIntervalSeconds) * time.Second)
 	defer func() {
 		ticker.Stop()
 		client.Conn.Close()
 	}()
 
+	// 왕복 지연 시간을 바로 측정할 수 있도록 연결 직후 핑 전송
+	client.Conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+	if err := client.Conn.WriteMessage(websocket.PingMessage, client.nextPing(time.Now())); err != nil {
+		return
+	}
+
 	for {
 		select {
 		case message, ok := <-client.Send:
@@ -176,27 +268,50 @@ func (h *Handler) writePump(client *Client) {
 				client.Conn.WriteMessage(websocket.CloseMessage, []byte{})
 				return
 			}
-
-			w, err := client.Conn.NextWriter(websocket.TextMessage)
-			if err != nil {
+			if err := client.writeMessage(message); err != nil {
 				return
 			}
-			w.Write(message)
-
-			if err := w.Close(); err != nil {
-				return
+		case <-client.done:
+			// 서버가 연결을 끊기로 함: 이미 넣어둔 패킷(에러 안내 등)을 마저 보내고 연결을 닫음
+			// (연결이 닫히면 readPump가 읽기 에러로 종료되면서 연결 해제 처리를 요청)
+			client.Conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+			for len(client.Send) > 0 {
+				message, ok := <-client.Send
+				if !ok || client.writeMessage(message) != nil {
+					return
+				}
 			}
+			client.Conn.WriteMessage(websocket.CloseMessage, []byte{})
+			return
 		case <-ticker.C:
 			client.Conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
-			if err := client.Conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+			if err := client.Conn.WriteMessage(websocket.PingMessage, client.nextPing(time.Now())); err != nil {
 				return
 			}
 		}
 	}
 }
 
+// 패킷 하나를 연결의 인코딩 방식(텍스트/바이너리)으로 쓰기 (writePump에서만 호출)
+func (c *Client) writeMessage(message []byte) error {
+	w, err := c.Conn.NextWriter(c.encoding.messageType())
+	if err != nil {
+		return err
+	}
+	w.Write(message)
+	return w.Close()
+}
+
 // 메시지 처리
 func (h *Handler) handleMessage(client *Client, message []byte) {
+	// 바이너리 인코딩이면 JSON으로 변환
+	message, err := client.encoding.requestToJSON(message)
+	if err != nil {
+		log.Printf("잘못된 %s 패킷: %v", client.encoding, err)
+		h.sendErrorWithSignal(client, 0, ErrCodeInvalidPacket, "잘못된 패킷 형식입니다")
+		return
+	}
+
 	// 클라이언트 요청 패킷 검증
 	request, err := ValidateRequestPacket(message)
 	if err != nil {
@@ -205,42 +320,25 @@ func (h *Handler) handleMessage(client *Client, message []byte) {
 		var rawRequest map[string]interface{}
 		if json.Unmarshal(message, &rawRequest) == nil {
 			if signal, ok := rawRequest["signal"].(float64); ok {
-				h.sendErrorWithSignal(client, int(signal), "잘못된 패킷 형식입니다")
+				h.sendErrorWithSignal(client, int(signal), ErrCodeInvalidPacket, "잘못된 패킷 형식입니다")
 				return
 			}
 		}
-		h.sendErrorWithSignal(client, 0, "잘못된 패킷 형식입니다")
-		return
-	}
-
-	// signal에 따른 요청 처리
-	switch request.Signal {
-	case RequestPing:
-		h.handlePing(client)
-	case RequestEnterRoom:
-		h.handleEnterRoom(client)
-	case RequestLeaveRoom:
-		h.handleLeaveRoom(client)
-	case RequestReadyGame:
-		h.handleReadyGame(client)
-	case RequestRingBell:
-		h.handleRingBell(client)
-	case RequestEmotion:
-		h.handleEmotion(client, request)
-	case RequestCreateAccount:
-		h.handleCreateAccount(client, request)
-	case RequestLogin:
-		h.handleLogin(client, request)
-	default:
-		log.Printf("알 수 없는 요청 signal: %d", request.Signal)
-		h.sendErrorWithSignal(client, request.Signal, "알 수 없는 요청입니다")
+		h.sendErrorWithSignal(client, 0, ErrCodeInvalidPacket, "잘못된 패킷 형식입니다")
+		return
 	}
+
+	// 등록된 요청 처리기로 전달
+	h.dispatch(client, request)
 }
 
 // 핑 처리
 func (h *Handler) handlePing(client *Client) {
+	rtt, jitter := client.latencyStats()
 	response := NewSuccessResponse(ResponsePong, map[string]interface{}{
 		"timestamp": time.Now().Unix(),
+		"rttMs":     rtt.Milliseconds(),
+		"jitterMs":  jitter.Milliseconds(),
 	})
 	h.sendToClient(client, response)
 }
@@ -248,500 +346,705 @@ func (h *Handler) handlePing(client *Client) {
 // 방 입장 처리
 func (h *Handler) handleEnterRoom(client *Client) {
 	// 이미 방에 참여한 상태인지 확인
-	if client.IsInRoom {
-		h.sendErrorWithSignal(client, RequestEnterRoom, "이미 방에 참여한 상태입니다")
+	if client.IsInRoom || client.IsSpectating {
+		h.sendErrorWithSignal(client, RequestEnterRoom, ErrCodeAlreadyInRoom, "이미 방에 참여한 상태입니다")
 		return
 	}
 
-	// 방 상태 확인
-	GlobalRoom.mu.RLock()
-	playerCount := len(GlobalRoom.players)
-	isGameStarted := GlobalRoom.isGameStarted
-	// 같은 ID의 플레이어가 이미 방에 있는지 확인
-	_, playerExists := GlobalRoom.players[client.ID]
-	GlobalRoom.mu.RUnlock()
+	// 입장 가능한 방이 없으면 새로운 방 생성
+	room := h.rooms.FindAvailableRoom()
+	if room == nil {
+		room = h.rooms.CreateRoom("", false, config.DefaultStartPolicy, config.GetDefaultConfig())
+	}
 
-	// 같은 ID의 플레이어가 이미 방에 있는지 확인
-	if playerExists {
-		h.sendErrorWithSignal(client, RequestEnterRoom, "같은 ID의 플레이어가 이미 방에 있습니다")
+	if !h.joinRoom(client, room, RequestEnterRoom) {
 		return
 	}
 
-	// 방이 꽉 찼는지 확인
-	if playerCount >= GlobalRoom.maxPlayers {
-		h.sendErrorWithSignal(client, RequestEnterRoom, "방이 꽉 찼습니다")
+	// 방 입장 성공 응답
+	response := NewSuccessResponse(ResponseEnterRoom, map[string]interface{}{})
+	h.sendToClient(client, response)
+
+	// 게임 시작 조건 확인
+	h.checkAndStartGame(room)
+}
+
+// 방 생성 처리
+func (h *Handler) handleCreateRoom(client *Client, createRoomData *RequestCreateRoomData) {
+	// 이미 방에 참여한 상태인지 확인
+	if client.IsInRoom || client.IsSpectating {
+		h.sendErrorWithSignal(client, RequestCreateRoom, ErrCodeAlreadyInRoom, "이미 방에 참여한 상태입니다")
 		return
 	}
 
-	// 게임이 이미 시작된 상태인지 확인
-	if isGameStarted {
-		h.sendErrorWithSignal(client, RequestEnterRoom, "게임이 이미 시작된 상태입니다")
-		return
+	// 게임 설정은 기본값 위에 요청에 포함된 값만 덮어씀
+	gameConfig := config.GetDefaultConfig()
+	if len(createRoomData.Config) > 0 && string(createRoomData.Config) != "null" {
+		if err := json.Unmarshal(createRoomData.Config, gameConfig); err != nil {
+			h.sendErrorWithSignal(client, RequestCreateRoom, ErrCodeInvalidGameConfig, "잘못된 게임 설정 형식입니다")
+			return
+		}
 	}
 
-	// 플레이어를 방에 추가
-	player := &Player{
-		ID:       client.ID,
-		Username: "Player" + generateRandomNumber(4), // 랜덤 숫자 4개를 사용자명으로
+	// 생략된 값은 기본값 사용
+	if createRoomData.StartPolicy == "" {
+		createRoomData.StartPolicy = config.DefaultStartPolicy
 	}
 
-	GlobalRoom.mu.Lock()
-	GlobalRoom.players[client.ID] = player
-	GlobalRoom.mu.Unlock()
+	// 데이터 유효성 검사
+	if !config.IsValidStartPolicy(createRoomData.StartPolicy) {
+		h.sendErrorWithSignal(client, RequestCreateRoom, ErrCodeStartPolicy, "알 수 없는 게임 시작 방식입니다")
+		return
+	}
+	if err := gameConfig.Validate(); err != nil {
+		h.sendErrorWithSignal(client, RequestCreateRoom, ErrCodeInvalidGameConfig, err.Error())
+		return
+	}
 
-	// 클라이언트 상태 업데이트
-	client.mu.Lock()
-	client.IsInRoom = true
-	client.Username = player.Username
-	client.mu.Unlock()
+	room := h.rooms.CreateRoom(createRoomData.Name, createRoomData.IsPrivate, createRoomData.StartPolicy, gameConfig)
+	if !h.joinRoom(client, room, RequestCreateRoom) {
+		h.rooms.RemoveRoom(room.id)
+		return
+	}
 
-	// 방 입장 성공 응답
-	response := NewSuccessResponse(ResponseEnterRoom, map[string]interface{}{})
+	// 방 생성 성공 응답 (비공개 방은 참여 코드 포함)
+	responseData := &ResponseCreateRoomData{
+		RoomInfoData: room.Info(),
+		Code:         room.code,
+	}
+	response := NewSuccessResponse(ResponseCreateRoom, responseData)
 	h.sendToClient(client, response)
 
-	log.Printf("플레이어 방 입장: %s (%s)", client.ID, player.Username)
-
-	// 현재 방 상태 로그 출력
-	GlobalRoom.mu.RLock()
-	currentPlayerCount := len(GlobalRoom.players)
-	GlobalRoom.mu.RUnlock()
-	log.Printf("현재 방 인원: %d/%d", currentPlayerCount, GlobalRoom.maxPlayers)
-
 	// 게임 시작 조건 확인
-	h.checkAndStartGame()
+	h.checkAndStartGame(room)
 }
 
-// 게임 시작 조건 확인 및 게임 시작
-func (h *Handler) checkAndStartGame() {
-	GlobalRoom.mu.Lock()
-	defer GlobalRoom.mu.Unlock()
+// 방 목록 조회 처리
+func (h *Handler) handleRoomList(client *Client) {
+	responseData := &ResponseRoomListData{
+		Rooms: h.rooms.PublicRoomInfos(),
+	}
+	response := NewSuccessResponse(ResponseRoomList, responseData)
+	h.sendToClient(client, response)
+}
 
-	// 게임이 이미 시작된 상태인지 확인
-	if GlobalRoom.isGameStarted {
+// 방 ID 또는 참여 코드로 방 참여 처리
+func (h *Handler) handleJoinRoom(client *Client, joinRoomData *RequestJoinRoomData) {
+	// 이미 방에 참여한 상태인지 확인
+	if client.IsInRoom || client.IsSpectating {
+		h.sendErrorWithSignal(client, RequestJoinRoom, ErrCodeAlreadyInRoom, "이미 방에 참여한 상태입니다")
 		return
 	}
 
-	// 방에 최대 인원이 들어왔는지 확인
-	if len(GlobalRoom.players) == GlobalRoom.maxPlayers {
-		// 게임 시작 상태로 변경
-		GlobalRoom.isGameStarted = true
-
-		// 준비 완료 상태 초기화
-		GlobalRoom.readyPlayers = make(map[string]bool)
+	room, ok := h.findTargetRoom(client, RequestJoinRoom, joinRoomData)
+	if !ok {
+		return
+	}
 
-		// 플레이어 정보를 랜덤한 순서로 수집
-		playerNames := make([]string, 0, len(GlobalRoom.players))
-		playerIDs := make([]string, 0, len(GlobalRoom.players))
+	if !h.joinRoom(client, room, RequestJoinRoom) {
+		return
+	}
 
-		// 플레이어 ID를 배열로 수집
-		playerIDList := make([]string, 0, len(GlobalRoom.players))
-		for playerID := range GlobalRoom.players {
-			playerIDList = append(playerIDList, playerID)
-		}
+	// 방 참여 성공 응답
+	response := NewSuccessResponse(ResponseJoinRoom, room.Info())
+	h.sendToClient(client, response)
 
-		// 플레이어 ID를 랜덤하게 섞기
-		shuffleStringSlice(playerIDList)
+	// 게임 시작 조건 확인
+	h.checkAndStartGame(room)
+}
 
-		for _, playerID := range playerIDList {
-			player := GlobalRoom.players[playerID]
-			playerNames = append(playerNames, player.Username)
-			playerIDs = append(playerIDs, player.ID)
+// 요청 데이터의 방 ID 또는 참여 코드로 대상 방 조회 (실패 시 요청 signal로 에러 전송)
+func (h *Handler) findTargetRoom(client *Client, signal int, joinRoomData *RequestJoinRoomData) (*Room, bool) {
+	// 참여 코드가 있으면 코드로, 없으면 방 ID로 방 조회
+	var room *Room
+	var exists bool
+	switch {
+	case joinRoomData.Code != "":
+		room, exists = h.rooms.FindRoomByCode(strings.ToUpper(joinRoomData.Code))
+	case joinRoomData.RoomID != "":
+		room, exists = h.rooms.GetRoom(joinRoomData.RoomID)
+		// 비공개 방은 방 ID만으로 참여할 수 없음
+		if exists && room.isPrivate {
+			h.sendErrorWithSignal(client, signal, ErrCodeRoomCodeRequired, "비공개 방은 참여 코드가 필요합니다")
+			return nil, false
 		}
+	default:
+		h.sendErrorWithSignal(client, signal, ErrCodeRoomCodeRequired, "방 ID 또는 참여 코드가 필요합니다")
+		return nil, false
+	}
 
-		// 각 플레이어에게 카드 분배 (인덱스 기반)
-		startingCards := config.StartingCards // 설정에서 가져온 시작 카드 수
-		GlobalRoom.playerCards = make([]int, len(GlobalRoom.players))
-		for i := range GlobalRoom.playerCards {
-			GlobalRoom.playerCards[i] = startingCards
-		}
+	if !exists {
+		h.sendErrorWithSignal(client, signal, ErrCodeRoomNotFound, "존재하지 않는 방입니다")
+		return nil, false
+	}
+	return room, true
+}
 
-		// 공개된 카드 배열 초기화
-		GlobalRoom.publicFruitIndexes = make([]int, len(GlobalRoom.players))
-		GlobalRoom.publicFruitCounts = make([]int, len(GlobalRoom.players))
-		GlobalRoom.openCards = make([]int, len(GlobalRoom.players))
-		// 초기값은 -1로 설정 (아직 카드가 공개되지 않음)
-		for i := range GlobalRoom.publicFruitIndexes {
-			GlobalRoom.publicFruitIndexes[i] = -1
-			GlobalRoom.publicFruitCounts[i] = -1
-			GlobalRoom.openCards[i] = 0
-		}
+// 관전 처리 (꽉 찼거나 게임이 진행 중인 방도 읽기 전용으로 관전 가능)
+func (h *Handler) handleSpectateRoom(client *Client, joinRoomData *RequestJoinRoomData) {
+	// 이미 방에 참여한 상태인지 확인
+	if client.IsInRoom || client.IsSpectating {
+		h.sendErrorWithSignal(client, RequestSpectateRoom, ErrCodeAlreadyInRoom, "이미 방에 참여한 상태입니다")
+		return
+	}
 
-		// 플레이어 인덱스 매핑 초기화 및 설정
-		GlobalRoom.playerIndexes = make(map[string]int)
-		for i, playerID := range playerIDs {
-			GlobalRoom.playerIndexes[playerID] = i
-		}
+	room, ok := h.findTargetRoom(client, RequestSpectateRoom, joinRoomData)
+	if !ok {
+		return
+	}
 
-		// 벨 누르기 상태 초기화
-		GlobalRoom.bellRung = false
-
-		log.Printf("게임 시작! 플레이어 수: %d, 플레이어들: %v, 각자 카드 %d장", len(GlobalRoom.players), playerNames, startingCards)
-		log.Printf("플레이어 인덱스 매핑: %v", GlobalRoom.playerIndexes)
-
-		// 각 클라이언트에게 게임 시작 패킷 전송
-		h.mu.RLock()
-		for client := range h.clients {
-			if client.IsInRoom {
-				// 클라이언트의 인덱스 찾기
-				myIndex := -1
-				for i, playerID := range playerIDs {
-					if playerID == client.ID {
-						myIndex = i
-						break
-					}
-				}
+	// 관전자 수 제한 확인
+	if !room.addSpectator(client, config.MaxSpectators) {
+		h.sendErrorWithSignal(client, RequestSpectateRoom, ErrCodeSpectatorsFull, "관전자 수가 가득 찼습니다")
+		return
+	}
 
-				if myIndex != -1 {
-					gameStartData := &GameStartData{
-						PlayerCount:   len(GlobalRoom.players),
-						PlayerNames:   playerNames,
-						MyIndex:       myIndex,
-						StartingCards: config.StartingCards, // 설정에서 가져온 시작 카드 수
-						GameTimeLimit: config.GameTimeLimit, // 설정에서 가져온 게임 제한시간
-					}
+	// 클라이언트 상태 업데이트 (플레이어로 집계되지 않음)
+	client.mu.Lock()
+	client.IsSpectating = true
+	client.Room = room
+	client.mu.Unlock()
 
-					response := NewSuccessResponse(ResponseStartGame, gameStartData)
-					h.sendToClient(client, response)
+	// 관전 시작 응답 (현재 방 상태 스냅샷 포함)
+	response := NewSuccessResponse(ResponseSpectateRoom, room.snapshot(client.ID))
+	h.sendToClient(client, response)
 
-					log.Printf("클라이언트 %s (%s)에게 게임 시작 패킷 전송 - 인덱스: %d, 제한시간: %d초", client.ID, client.Username, myIndex, config.GameTimeLimit)
-				}
-			}
-		}
-		h.mu.RUnlock()
-	}
+	log.Printf("관전 시작: %s - 방: %s", client.ID, room.id)
 }
 
-// 방 나가기 처리
-func (h *Handler) handleLeaveRoom(client *Client) {
-	// 방에 참여하지 않은 상태인지 확인
-	if !client.IsInRoom {
-		h.sendErrorWithSignal(client, RequestLeaveRoom, "방에 참여하지 않은 상태입니다")
-		return
+// 관전 종료 처리
+func (h *Handler) stopSpectating(client *Client) {
+	if room := client.Room; room != nil {
+		room.removeSpectator(client)
+		log.Printf("관전 종료: %s - 방: %s", client.ID, room.id)
 	}
 
-	// 게임이 시작된 상태인지 확인
-	GlobalRoom.mu.RLock()
-	isGameStarted := GlobalRoom.isGameStarted
-	GlobalRoom.mu.RUnlock()
+	client.mu.Lock()
+	client.IsSpectating = false
+	client.Room = nil
+	client.mu.Unlock()
+}
+
+// 클라이언트를 지정된 방에 플레이어로 추가 (실패 시 요청 signal로 에러 전송)
+func (h *Handler) joinRoom(client *Client, room *Room, signal int) bool {
+	// 좌석 확보가 끝날 때까지 client.mu를 잡아 다른 고루틴(매칭 루프, 연결 해제 처리)이 중간 상태를 보지 못하게 함
+	client.mu.Lock()
+	if client.isClosed() {
+		// 연결 해제 처리가 이미 시작된 클라이언트는 앉히지 않음 (앉히면 아무도 치우지 않는 빈 좌석이 남음)
+		client.mu.Unlock()
+		log.Printf("연결이 끊긴 클라이언트 방 입장 거부: %s - 방: %s", client.ID, room.id)
+		return false
+	}
+	if client.IsInRoom || client.IsSpectating {
+		client.mu.Unlock()
+		h.sendErrorWithSignal(client, signal, ErrCodeAlreadyInRoom, "이미 방에 참여한 상태입니다")
+		return false
+	}
 
-	// 게임이 이미 시작된 상태인지 확인
-	if isGameStarted {
-		h.sendErrorWithSignal(client, RequestLeaveRoom, "게임이 이미 시작된 상태입니다")
-		return
+	// 플레이어를 방에 추가 (로그인한 계정의 닉네임을 사용자명으로)
+	player, err := room.game.AddPlayer(client.ID, client.Nickname)
+	if err != nil {
+		client.mu.Unlock()
+		h.sendErrorFor(client, signal, err)
+		return false
 	}
 
-	// 플레이어를 방에서 제거
-	GlobalRoom.mu.Lock()
-	delete(GlobalRoom.players, client.ID)
-	GlobalRoom.mu.Unlock()
+	room.addClient(client)
 
 	// 클라이언트 상태 업데이트
-	client.mu.Lock()
-	client.IsInRoom = false
-	client.Username = ""
+	client.IsInRoom = true
+	client.Room = room
+	client.Username = player.Username
 	client.mu.Unlock()
 
-	// 방 나가기 성공 응답
-	response := NewSuccessResponse(ResponseLeaveRoom, map[string]interface{}{})
-	h.sendToClient(client, response)
+	log.Printf("플레이어 방 입장: %s (%s) - 방: %s", client.ID, player.Username, room.id)
+
+	// 현재 방 상태 로그 출력
+	log.Printf("현재 방 인원: %d/%d", room.game.PlayerCount(), room.game.MaxPlayers())
 
-	log.Printf("플레이어 방 퇴장: %s", client.ID)
-
-	// 게임이 시작된 상태였다면 게임 상태 리셋
-	if isGameStarted {
-		GlobalRoom.mu.Lock()
-		GlobalRoom.isGameStarted = false
-		GlobalRoom.playerCards = nil         // 카드 배열 초기화
-		GlobalRoom.readyPlayers = nil        // 준비 완료 상태 초기화
-		GlobalRoom.isCardGameStarted = false // 카드 게임 상태 초기화
-		GlobalRoom.publicFruitIndexes = nil  // 공개된 카드 배열 초기화
-		GlobalRoom.publicFruitCounts = nil   // 공개된 카드 배열 초기화
-		GlobalRoom.openCards = nil           // 공개된 카드 개수 배열 초기화
-		GlobalRoom.bellRung = false          // 벨 누르기 상태 초기화
-		GlobalRoom.isTimeExpired = false     // 시간제한 상태 초기화
-		GlobalRoom.playerIndexes = nil       // 플레이어 인덱스 매핑 초기화
-		if GlobalRoom.cardTimer != nil {
-			GlobalRoom.cardTimer.Stop() // 카드 타이머 정지
-			GlobalRoom.cardTimer = nil
+	return true
+}
+
+// 방의 게임 시작 방식에 따라 게임 시작 조건 확인 및 게임 시작
+func (h *Handler) checkAndStartGame(room *Room) {
+	switch room.startPolicy {
+	case config.StartPolicyFullRoom:
+		// 방에 최대 인원이 들어왔는지 확인
+		if room.game.PlayerCount() != room.game.MaxPlayers() {
+			return
+		}
+	case config.StartPolicyAllReady:
+		// 최소 인원 이상이 모두 준비했는지 확인
+		if !room.game.CanStartGame() {
+			return
 		}
-		GlobalRoom.mu.Unlock()
-		log.Printf("플레이어 퇴장으로 인한 게임 상태 리셋")
+	case config.StartPolicyCountdown:
+		h.updateStartCountdown(room)
+		return
+	default:
+		// 방장이 시작 요청을 보낼 때까지 대기
+		return
 	}
+
+	h.startGame(room)
 }
 
-// 준비 완료 처리
-func (h *Handler) handleReadyGame(client *Client) {
-	// 방에 참여하지 않은 상태인지 확인
-	if !client.IsInRoom {
-		h.sendErrorWithSignal(client, RequestReadyGame, "방에 참여하지 않은 상태입니다")
+// 최소 인원이 모이면 카운트다운 시작, 인원이 줄면 취소 (최대 인원이 모이면 바로 시작)
+func (h *Handler) updateStartCountdown(room *Room) {
+	if room.game.IsGameStarted() {
 		return
 	}
 
-	// 게임이 시작되지 않은 상태인지 확인
-	GlobalRoom.mu.RLock()
-	isGameStarted := GlobalRoom.isGameStarted
-	GlobalRoom.mu.RUnlock()
+	playerCount := room.game.PlayerCount()
+	if playerCount >= room.game.MaxPlayers() {
+		room.mu.Lock()
+		if room.startTimer != nil {
+			room.startTimer.Stop()
+			room.startTimer = nil
+		}
+		room.mu.Unlock()
 
-	if !isGameStarted {
-		h.sendErrorWithSignal(client, RequestReadyGame, "게임이 시작되지 않은 상태입니다")
+		h.startGame(room)
 		return
 	}
 
-	// 플레이어를 준비 완료 상태로 설정
-	GlobalRoom.mu.Lock()
-	GlobalRoom.readyPlayers[client.ID] = true
-	readyCount := len(GlobalRoom.readyPlayers)
-	totalPlayers := len(GlobalRoom.players)
-	GlobalRoom.mu.Unlock()
+	room.mu.Lock()
+	var countdownData *StartCountdownData
+	switch {
+	case playerCount >= room.game.MinPlayers() && room.startTimer == nil:
+		room.startTimer = time.AfterFunc(time.Duration(config.StartCountdown)*time.Second, func() {
+			room.mu.Lock()
+			room.startTimer = nil
+			room.mu.Unlock()
+
+			h.startGame(room)
+		})
+		countdownData = &StartCountdownData{Seconds: config.StartCountdown}
+		log.Printf("게임 시작 카운트다운 시작 - 방: %s, %d초 후 시작", room.id, config.StartCountdown)
+	case playerCount < room.game.MinPlayers() && room.startTimer != nil:
+		room.startTimer.Stop()
+		room.startTimer = nil
+		countdownData = &StartCountdownData{IsCancelled: true}
+		log.Printf("게임 시작 카운트다운 취소 - 방: %s, 인원 부족 (%d명)", room.id, playerCount)
+	}
+	room.mu.Unlock()
+
+	// 카운트다운 상태가 바뀐 경우 방의 모든 클라이언트에게 알림
+	if countdownData != nil {
+		response := NewSuccessResponse(ResponseStartCountdown, countdownData)
+		h.broadcastToRoom(room, response)
+	}
+}
+
+// 게임 시작 (이미 시작되었거나 인원이 부족하면 에러)
+func (h *Handler) startGame(room *Room) error {
+	start, err := room.game.StartGame()
+	if err != nil {
+		return err
+	}
+
+	log.Printf("게임 시작! 방: %s, 플레이어 수: %d, 플레이어들: %v, 각자 카드 %d장", room.id, len(start.PlayerIDs), start.PlayerNames, start.StartingCards)
 
-	log.Printf("플레이어 준비 완료: %s (%s) - 준비: %d/%d", client.ID, client.Username, readyCount, totalPlayers)
+	// 전적 저장을 위해 좌석별 로그인 계정 기록
+	room.recordSeatAccounts()
 
-	// 모든 플레이어가 준비 완료했는지 확인
-	if readyCount == totalPlayers {
-		log.Printf("모든 플레이어 준비 완료! 게임 시작!")
+	// 각 클라이언트에게 게임 시작 패킷 전송
+	for _, client := range room.memberClients() {
+		myIndex, exists := room.game.PlayerIndex(client.ID)
+		if !client.IsInRoom || !exists {
+			continue
+		}
 
-		// 카드 게임 시작
-		GlobalRoom.mu.Lock()
-		GlobalRoom.isCardGameStarted = true
-		GlobalRoom.currentPlayerIndex = 0 // 첫 번째 플레이어부터 시작
-		GlobalRoom.mu.Unlock()
+		gameStartData := &GameStartData{
+			PlayerCount:   len(start.PlayerIDs),
+			PlayerNames:   start.PlayerNames,
+			MyIndex:       myIndex,
+			StartingCards: start.StartingCards,
+			GameTimeLimit: room.gameConfig.GameTimeLimit,
+			Config:        room.gameConfig,
+		}
 
-		// 카드 공개 타이머 시작
-		h.startCardTimer()
+		response := NewSuccessResponse(ResponseStartGame, gameStartData)
+		h.sendToClient(client, response)
 
-		// 게임 제한시간 타이머 시작
-		h.startGameTimer()
+		log.Printf("클라이언트 %s (%s)에게 게임 시작 패킷 전송 - 인덱스: %d, 제한시간: %d초", client.ID, client.Username, myIndex, room.gameConfig.GameTimeLimit)
+	}
 
-		// 모든 클라이언트에게 게임 시작 패킷 전송
-		h.mu.RLock()
-		for c := range h.clients {
-			if c.IsInRoom {
-				response := NewSuccessResponse(ResponseReadyGame, map[string]interface{}{})
-				h.sendToClient(c, response)
-				log.Printf("클라이언트 %s (%s)에게 게임 시작 패킷 전송", c.ID, c.Username)
-			}
+	// 관전자에게는 좌석 없이(-1) 게임 시작 패킷 전송
+	for _, client := range room.spectatorClients() {
+		gameStartData := &GameStartData{
+			PlayerCount:   len(start.PlayerIDs),
+			PlayerNames:   start.PlayerNames,
+			MyIndex:       -1,
+			StartingCards: start.StartingCards,
+			GameTimeLimit: room.gameConfig.GameTimeLimit,
+			Config:        room.gameConfig,
 		}
-		h.mu.RUnlock()
+		response := NewSuccessResponse(ResponseStartGame, gameStartData)
+		h.sendToClient(client, response)
 	}
+
+	// 봇은 게임 화면이 없으므로 바로 준비 완료 처리
+	h.markBotsReady(room)
+	return nil
 }
 
-// 플레이어 인덱스로 카드 개수 조회
-func (r *Room) GetPlayerCardCount(playerIndex int) int {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+// 방장의 게임 시작 요청 처리 (방장이 시작하는 방에서만 가능)
+func (h *Handler) handleStartGame(client *Client) {
+	room := client.Room
+
+	if room.startPolicy != config.StartPolicyHost {
+		h.sendErrorWithSignal(client, RequestStartGame, ErrCodeStartPolicy, "방장이 시작하는 방이 아닙니다")
+		return
+	}
+	if !room.isHost(client.ID) {
+		h.sendErrorWithSignal(client, RequestStartGame, ErrCodeNotHost, "방장만 게임을 시작할 수 있습니다")
+		return
+	}
 
-	if playerIndex < 0 || playerIndex >= len(r.playerCards) {
-		return 0
+	if err := h.startGame(room); err != nil {
+		h.sendErrorFor(client, RequestStartGame, err)
+		return
 	}
-	return r.playerCards[playerIndex]
+
+	log.Printf("방장이 게임 시작: %s (%s) - 방: %s", client.ID, client.Username, room.id)
 }
 
-// 플레이어 인덱스로 카드 개수 설정
-func (r *Room) SetPlayerCardCount(playerIndex int, cardCount int) {
-	r.mu.Lock()
-	defer r.mu.Unlock()
+// 대기실 준비 상태 변경 처리 (모두 준비 방식의 방에서 게임 시작 조건으로 사용)
+func (h *Handler) handleToggleReady(client *Client) {
+	room := client.Room
+
+	// 게임이 이미 시작된 상태인지 확인
+	if room.game.IsGameStarted() {
+		h.sendErrorWithSignal(client, RequestToggleReady, ErrCodeGameStarted, "게임이 이미 시작된 상태입니다")
+		return
+	}
+
+	isReady := room.game.TogglePlayerReady(client.ID)
+	readyCount, playerCount := room.game.LobbyReadyCount()
+
+	log.Printf("플레이어 대기실 준비 변경: %s (%s) - 준비: %v (%d/%d)", client.ID, client.Username, isReady, readyCount, playerCount)
 
-	if playerIndex >= 0 && playerIndex < len(r.playerCards) {
-		r.playerCards[playerIndex] = cardCount
+	// 방의 모든 클라이언트에게 준비 상태 전송
+	toggleReadyData := &ToggleReadyData{
+		Username:    client.Username,
+		IsReady:     isReady,
+		ReadyCount:  readyCount,
+		PlayerCount: playerCount,
 	}
+	response := NewSuccessResponse(ResponseToggleReady, toggleReadyData)
+	h.broadcastToRoom(room, response)
+
+	// 게임 시작 조건 확인
+	h.checkAndStartGame(room)
 }
 
-// 플레이어 인덱스로 공개된 카드 과일 인덱스 조회
-func (r *Room) GetPublicFruitIndex(playerIndex int) int {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+// 방 나가기 처리
+func (h *Handler) handleLeaveRoom(client *Client) {
+	// 관전 중이면 관전 종료
+	if client.IsSpectating {
+		h.stopSpectating(client)
+		response := NewSuccessResponse(ResponseLeaveRoom, map[string]interface{}{})
+		h.sendToClient(client, response)
+		return
+	}
 
-	if playerIndex < 0 || playerIndex >= len(r.publicFruitIndexes) {
-		return -1
+	// 방에 참여하지 않은 상태인지 확인
+	room := client.Room
+	if !client.IsInRoom || room == nil {
+		h.sendErrorWithSignal(client, RequestLeaveRoom, ErrCodeNotInRoom, "방에 참여하지 않은 상태입니다")
+		return
 	}
-	return r.publicFruitIndexes[playerIndex]
+
+	// 게임이 이미 시작된 상태인지 확인
+	if room.game.IsGameStarted() {
+		h.sendErrorWithSignal(client, RequestLeaveRoom, ErrCodeGameStarted, "게임이 이미 시작된 상태입니다")
+		return
+	}
+
+	// 플레이어를 방에서 제거
+	h.removePlayerFromRoom(room, client)
+
+	// 방 나가기 성공 응답
+	response := NewSuccessResponse(ResponseLeaveRoom, map[string]interface{}{})
+	h.sendToClient(client, response)
+
+	log.Printf("플레이어 방 퇴장: %s - 방: %s", client.ID, room.id)
 }
 
-// 플레이어 인덱스로 공개된 카드 과일 개수 조회
-func (r *Room) GetPublicFruitCount(playerIndex int) int {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+// 게임 시작 전 플레이어를 방에서 제거 (빈 방은 삭제)
+func (h *Handler) removePlayerFromRoom(room *Room, client *Client) {
+	remainingPlayers := room.game.RemovePlayer(client.ID)
+
+	room.mu.Lock()
+	delete(room.lastEmotionTimes, client.ID)
+	room.mu.Unlock()
 
-	if playerIndex < 0 || playerIndex >= len(r.publicFruitCounts) {
-		return -1
+	room.removeClient(client)
+
+	// 클라이언트 상태 업데이트
+	client.mu.Lock()
+	client.IsInRoom = false
+	client.Username = ""
+	client.Room = nil
+	client.mu.Unlock()
+
+	// 아무도 남지 않았거나 봇만 남은 방은 삭제
+	if remainingPlayers == 0 || room.connectedCount() == 0 {
+		room.mu.Lock()
+		room.stopTimersLocked()
+		room.mu.Unlock()
+
+		h.rooms.RemoveRoom(room.id)
+		return
 	}
-	return r.publicFruitCounts[playerIndex]
+
+	// 남은 플레이어로 게임 시작 조건 다시 확인 (카운트다운 취소, 준비하지 않은 플레이어가 나간 경우 등)
+	h.checkAndStartGame(room)
 }
 
-// 모든 플레이어의 공개된 카드 정보 조회
-func (r *Room) GetAllPublicCards() ([]int, []int) {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+// 방 상태 스냅샷 요청 처리 (패킷 유실 등으로 어긋난 클라이언트 상태 재동기화)
+func (h *Handler) handleSnapshot(client *Client) {
+	// 방에 참여하거나 관전 중인 상태인지 확인
+	room := client.Room
+	if (!client.IsInRoom && !client.IsSpectating) || room == nil {
+		h.sendErrorWithSignal(client, RequestSnapshot, ErrCodeNotInRoom, "방에 참여하지 않은 상태입니다")
+		return
+	}
+
+	response := NewSuccessResponse(ResponseSnapshot, room.snapshot(client.ID))
+	h.sendToClient(client, response)
+}
 
-	fruitIndexes := make([]int, len(r.publicFruitIndexes))
-	fruitCounts := make([]int, len(r.publicFruitCounts))
-	copy(fruitIndexes, r.publicFruitIndexes)
-	copy(fruitCounts, r.publicFruitCounts)
+// 봇 추가 처리 (게임 시작 전 빈 좌석을 봇으로 채움)
+func (h *Handler) handleAddBot(client *Client, addBotData *RequestAddBotData) {
+	room := client.Room
 
-	return fruitIndexes, fruitCounts
+	if !room.isHost(client.ID) {
+		h.sendErrorWithSignal(client, RequestAddBot, ErrCodeNotHost, "방장만 봇을 추가할 수 있습니다")
+		return
+	}
+
+	bot, err := h.addBot(room, addBotData.Difficulty)
+	if err != nil {
+		h.sendErrorFor(client, RequestAddBot, err)
+		return
+	}
+
+	// 봇 추가 성공 응답
+	responseData := &ResponseBotData{
+		RoomInfoData: room.Info(),
+		BotID:        bot.id,
+		Username:     bot.username,
+		Difficulty:   bot.difficulty,
+	}
+	response := NewSuccessResponse(ResponseAddBot, responseData)
+	h.sendToClient(client, response)
+
+	// 게임 시작 조건 확인
+	h.checkAndStartGame(room)
 }
 
-// 같은 종류의 과일이 정확히 5개가 공개되어 있는지 확인
-func (r *Room) IsBellRingingTime() bool {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+// 봇 제거 처리 (botId가 없으면 아무 봇이나 제거)
+func (h *Handler) handleRemoveBot(client *Client, removeBotData *RequestRemoveBotData) {
+	room := client.Room
 
-	// 각 과일 종류별로 개수를 세기
-	fruitCounts := make(map[int]int)
+	if !room.isHost(client.ID) {
+		h.sendErrorWithSignal(client, RequestRemoveBot, ErrCodeNotHost, "방장만 봇을 제거할 수 있습니다")
+		return
+	}
 
-	for i, fruitIndex := range r.publicFruitIndexes {
-		// 카드가 공개되지 않은 경우 (-1) 무시
-		if fruitIndex == -1 {
-			continue
-		}
+	// 게임이 이미 시작된 상태인지 확인
+	if room.game.IsGameStarted() {
+		h.sendErrorWithSignal(client, RequestRemoveBot, ErrCodeGameStarted, "게임이 이미 시작된 상태입니다")
+		return
+	}
 
-		// 해당 과일의 개수에 현재 카드의 과일 개수를 더함
-		fruitCounts[fruitIndex] += r.publicFruitCounts[i]
+	bot := room.removeBot(removeBotData.BotID)
+	if bot == nil {
+		h.sendErrorFor(client, RequestRemoveBot, ErrBotNotFound)
+		return
 	}
+	room.game.RemovePlayer(bot.id)
 
-	// 어떤 과일이라도 정확히 설정된 개수가 있으면 true 반환
-	for _, count := range fruitCounts {
-		if count == config.BellRingingFruitCount {
-			return true
-		}
+	log.Printf("봇 제거: %s (%s) - 방: %s", bot.id, bot.username, room.id)
+
+	// 봇 제거 성공 응답
+	responseData := &ResponseBotData{
+		RoomInfoData: room.Info(),
+		BotID:        bot.id,
+		Username:     bot.username,
+		Difficulty:   bot.difficulty,
 	}
+	response := NewSuccessResponse(ResponseRemoveBot, responseData)
+	h.sendToClient(client, response)
 
-	return false
+	// 게임 시작 조건 확인
+	h.checkAndStartGame(room)
 }
 
-// 특정 과일 종류가 정확히 5개가 공개되어 있는지 확인
-func (r *Room) IsSpecificFruitBellRingingTime(fruitIndex int) bool {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+// 준비 완료 처리
+func (h *Handler) handleReadyGame(client *Client) {
+	room := client.Room
+
+	if err := h.markReady(room, client.ID, client.Username); err != nil {
+		h.sendErrorFor(client, RequestReadyGame, err)
+	}
+}
 
-	totalCount := 0
+// 플레이어를 게임 화면 준비 완료 상태로 설정하고, 모두 준비되면 카드 게임 시작 (봇도 같은 경로 사용)
+func (h *Handler) markReady(room *Room, playerID, username string) error {
+	// 플레이어를 준비 완료 상태로 설정 (게임이 시작되지 않았으면 에러)
+	ready, err := room.game.MarkReady(playerID)
+	if err != nil {
+		return err
+	}
 
-	for i, publicFruitIndex := range r.publicFruitIndexes {
-		// 카드가 공개되지 않은 경우 (-1) 무시
-		if publicFruitIndex == -1 {
-			continue
-		}
+	log.Printf("플레이어 준비 완료: %s (%s) - 준비: %d/%d", playerID, username, ready.ReadyCount, ready.TotalPlayers)
 
-		// 지정된 과일 종류와 일치하는 경우 개수에 추가
-		if publicFruitIndex == fruitIndex {
-			totalCount += r.publicFruitCounts[i]
-		}
+	// 연결된 플레이어가 모두 준비 완료했는지 확인
+	if ready.AllReady {
+		h.beginCardGame(room)
 	}
+	return nil
+}
+
+// 카드 게임 시작 (연결된 플레이어가 모두 준비를 마친 뒤 호출)
+func (h *Handler) beginCardGame(room *Room) {
+	log.Printf("모든 플레이어 준비 완료! 게임 시작! - 방: %s", room.id)
+
+	// 카드 공개 타이머와 게임 제한시간 타이머 시작
+	room.mu.Lock()
+	h.startCardTimer(room)
+	h.startGameTimer(room)
+	room.mu.Unlock()
 
-	return totalCount == config.BellRingingFruitCount
+	// 방의 모든 클라이언트에게 게임 시작 패킷 전송
+	response := NewSuccessResponse(ResponseReadyGame, map[string]interface{}{})
+	h.broadcastToRoom(room, response)
 }
 
 // 클라이언트에게 메시지 전송
 func (h *Handler) sendToClient(client *Client, message interface{}) {
-	var data []byte
-	var err error
-
-	// Packet 타입인 경우 ToJSONWithLog 사용
-	if packet, ok := message.(*ResponsePacket); ok {
-		data, err = packet.ToJSONWithLog()
-	} else {
-		// 기존 호환성을 위한 fallback
-		data, err = json.Marshal(message)
-		if err != nil {
-			log.Printf("메시지 마샬링 오류: %v", err)
-			return
-		}
-	}
+	h.sendFrame(client, newPacketFrame(message))
+}
 
+// 클라이언트의 인코딩 방식으로 프레임 전송
+func (h *Handler) sendFrame(client *Client, frame *packetFrame) {
+	data, err := frame.bytes(client.encoding)
 	if err != nil {
 		return
 	}
+	h.send(client, data)
+}
+
+// 전송 채널에 패킷 추가 (전송 버퍼가 가득 찬 클라이언트는 연결 종료)
+func (h *Handler) send(client *Client, data []byte) {
+	client.sendMu.Lock()
+	defer client.sendMu.Unlock()
 
+	if client.sendClosed {
+		return
+	}
 	select {
 	case client.Send <- data:
 	default:
-		close(client.Send)
-		delete(h.clients, client)
+		if !client.closing.Load() {
+			log.Printf("전송 버퍼가 가득 차 연결 종료: %s", client.ID)
+		}
+		client.disconnect()
 	}
 }
 
-// 모든 클라이언트에게 브로드캐스트
-func (h *Handler) broadcastToAll(message interface{}) {
-	var data []byte
-	var err error
+// 전송 채널 닫기 (이미 닫았으면 false, Run의 연결 해제 처리에서만 호출)
+func (c *Client) closeSend() bool {
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
 
-	// Packet 타입인 경우 ToJSONWithLog 사용
-	if packet, ok := message.(*ResponsePacket); ok {
-		data, err = packet.ToJSONWithLog()
-	} else {
-		// 기존 호환성을 위한 fallback
-		data, err = json.Marshal(message)
-		if err != nil {
-			log.Printf("메시지 마샬링 오류: %v", err)
-			return
-		}
+	if c.sendClosed {
+		return false
 	}
+	c.sendClosed = true
+	close(c.Send)
+	return true
+}
 
-	if err != nil {
+// 연결 해제 처리가 시작됐거나 서버가 연결을 끊기로 한 상태인지 확인
+func (c *Client) isClosed() bool {
+	if c.closing.Load() {
+		return true
+	}
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+	return c.sendClosed
+}
+
+// 서버가 먼저 연결 끊기 (어느 고루틴에서든 호출 가능)
+// readPump는 남은 요청을 처리하지 않고, writePump가 이미 넣어둔 패킷을 마저 보낸 뒤 연결을 닫으면
+// readPump가 읽기 에러로 종료되면서 연결 해제 처리를 요청함
+func (c *Client) disconnect() {
+	if c.closing.Swap(true) {
 		return
 	}
+	close(c.done)
+}
+
+// 모든 클라이언트에게 브로드캐스트
+func (h *Handler) broadcastToAll(message interface{}) {
+	frame := newPacketFrame(message)
 
 	h.mu.RLock()
 	for client := range h.clients {
-		select {
-		case client.Send <- data:
-		default:
-			close(client.Send)
-			delete(h.clients, client)
-		}
+		h.sendFrame(client, frame)
 	}
 	h.mu.RUnlock()
 }
 
 // 특정 클라이언트를 제외한 모든 클라이언트에게 브로드캐스트
 func (h *Handler) broadcastToOthers(excludeClient *Client, message interface{}) {
-	var data []byte
-	var err error
-
-	// Packet 타입인 경우 ToJSONWithLog 사용
-	if packet, ok := message.(*ResponsePacket); ok {
-		data, err = packet.ToJSONWithLog()
-	} else {
-		// 기존 호환성을 위한 fallback
-		data, err = json.Marshal(message)
-		if err != nil {
-			log.Printf("메시지 마샬링 오류: %v", err)
-			return
-		}
-	}
-
-	if err != nil {
-		return
-	}
+	frame := newPacketFrame(message)
 
 	h.mu.RLock()
 	for client := range h.clients {
 		if client != excludeClient {
-			select {
-			case client.Send <- data:
-			default:
-				close(client.Send)
-				delete(h.clients, client)
-			}
+			h.sendFrame(client, frame)
 		}
 	}
 	h.mu.RUnlock()
 }
 
+// 방에 참여 중인 클라이언트와 관전자들에게만 브로드캐스트 (인코딩 방식별로 한 번만 마샬링)
+func (h *Handler) broadcastToRoom(room *Room, message interface{}) {
+	frame := newPacketFrame(message)
+	for _, client := range room.memberClients() {
+		if client.IsInRoom {
+			h.sendFrame(client, frame)
+		}
+	}
+	for _, client := range room.spectatorClients() {
+		h.sendFrame(client, frame)
+	}
+}
+
 // 에러 메시지 전송 (기본 signal 0 사용)
-func (h *Handler) sendError(client *Client, message string) {
-	log.Printf("에러 발생: %s", message)
-	errorResponse := NewErrorResponse(0, message)
+func (h *Handler) sendError(client *Client, errorCode ErrorCode, message string) {
+	log.Printf("에러 발생 (code: %d): %s", errorCode, message)
+	errorResponse := NewErrorResponse(0, errorCode, message)
 	h.sendToClient(client, errorResponse)
 }
 
 // 에러 메시지 전송 (특정 signal 사용)
-func (h *Handler) sendErrorWithSignal(client *Client, signal int, message string) {
-	log.Printf("에러 발생 (signal: %d): %s", signal, message)
-	errorResponse := NewErrorResponse(signal, message)
+func (h *Handler) sendErrorWithSignal(client *Client, signal int, errorCode ErrorCode, message string) {
+	log.Printf("에러 발생 (signal: %d, code: %d): %s", signal, errorCode, message)
+	errorResponse := NewErrorResponse(signal, errorCode, message)
 	h.sendToClient(client, errorResponse)
 }
 
+// 에러 값으로 에러 메시지 전송 (에러 코드는 errorCodeFor로 결정)
+func (h *Handler) sendErrorFor(client *Client, signal int, err error) {
+	h.sendErrorWithSignal(client, signal, errorCodeFor(err), err.Error())
+}
+
 // 클라이언트 ID 생성
 func generateClientID() string {
 	return time.Now().Format("20060102150405") + "-" + randomString(6)
@@ -757,6 +1060,16 @@ func randomString(length int) string {
 	return string(b)
 }
 
+// 랜덤 코드 생성 (영문 대문자와 숫자, 방 ID와 참여 코드에 사용)
+func generateRandomCode(length int) string {
+	const charset = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	b := make([]byte, length)
+	for i := range b {
+		b[i] = charset[rand.Intn(len(charset))]
+	}
+	return string(b)
+}
+
 // 랜덤 숫자 생성 (지정된 자릿수)
 func generateRandomNumber(digits int) string {
 	const charset = "0123456789"
@@ -769,6 +1082,9 @@ func generateRandomNumber(digits int) string {
 
 // 핸들러 실행
 func (h *Handler) Run() {
+	go h.runMatchmaking()
+	go h.runLatencyReports()
+
 	for {
 		select {
 		case client := <-h.register:
@@ -779,394 +1095,351 @@ func (h *Handler) Run() {
 
 		case client := <-h.unregister:
 			h.mu.Lock()
-			if _, ok := h.clients[client]; ok {
-				delete(h.clients, client)
-				close(client.Send)
-				log.Printf("클라이언트 연결 해제: %s", client.ID)
-			}
+			delete(h.clients, client)
 			h.mu.Unlock()
 
+			// 방/대기열/재접속 세션 정리는 Send를 처음 닫을 때 한 번만 (두 번 정리하면 게임 중 끊긴 플레이어의 재접속 세션이 지워짐)
+			if !client.closeSend() {
+				continue
+			}
+			log.Printf("클라이언트 연결 해제: %s", client.ID)
+
+			// 매칭을 기다리던 중이면 대기열에서 제거
+			h.queue.remove(client)
+
+			// 방 참여 상태는 Send를 닫은 뒤 client.mu로 읽음 (이후의 joinRoom은 닫힌 연결을 앉히지 않음)
+			client.mu.Lock()
+			room, isInRoom, isSpectating := client.Room, client.IsInRoom, client.IsSpectating
+			client.mu.Unlock()
+
 			// 방에 참여한 상태라면 처리
-			if client.IsInRoom {
-				GlobalRoom.mu.RLock()
-				isGameStarted := GlobalRoom.isGameStarted
-				GlobalRoom.mu.RUnlock()
+			if !isInRoom || room == nil {
+				// 관전 중이었다면 관전 종료
+				if isSpectating {
+					h.stopSpectating(client)
+				}
 
-				if !isGameStarted {
+				// 되찾을 좌석이 없으므로 재접속 세션 삭제
+				h.sessions.remove(client.resumeToken)
+			} else {
+				if !room.game.IsGameStarted() {
 					// 게임이 시작되지 않은 상태: LeaveRoom과 동일하게 처리
 					log.Printf("게임 시작 전 플레이어 연결 해제: %s (%s)", client.ID, client.Username)
 
 					// 플레이어를 방에서 제거
-					GlobalRoom.mu.Lock()
-					delete(GlobalRoom.players, client.ID)
-					GlobalRoom.mu.Unlock()
-
-					// 클라이언트 상태 업데이트
-					client.mu.Lock()
-					client.IsInRoom = false
-					client.Username = ""
-					client.mu.Unlock()
+					h.removePlayerFromRoom(room, client)
 
-					log.Printf("플레이어 방에서 제거: %s", client.ID)
+					log.Printf("플레이어 방에서 제거: %s - 방: %s", client.ID, room.id)
+					h.sessions.remove(client.resumeToken)
 				} else {
-					// 게임이 시작된 상태: 단순히 브로드캐스트에서 제외
-					log.Printf("게임 진행 중 플레이어 연결 해제: %s (%s) - 브로드캐스트에서 제외", client.ID, client.Username)
+					// 게임이 시작된 상태: 브로드캐스트에서 제외하고 재접속 대기
+					log.Printf("게임 진행 중 플레이어 연결 해제: %s (%s) - 브로드캐스트에서 제외, %d초간 재접속 대기", client.ID, client.Username, config.ReconnectGracePeriod)
 
 					// 클라이언트 상태만 업데이트 (방에서는 제거하지 않음)
+					room.removeClient(client)
+					room.game.SetPlayerActive(client.ID, false)
+					h.sessions.markDisconnected(client.resumeToken, room, client.AccountID, client.Nickname, client.sessionToken)
 					client.mu.Lock()
 					client.IsInRoom = false
 					client.Username = ""
+					client.Room = nil
 					client.mu.Unlock()
-				}
 
-				// 모든 플레이어가 연결을 끊었는지 확인
-				h.checkAllPlayersDisconnected()
+					// 준비 단계였다면 남은 플레이어가 끊긴 플레이어를 기다리지 않도록 준비 상태 다시 확인
+					if room.game.StartCardGameIfReady() {
+						h.beginCardGame(room)
+					}
+
+					// 모든 플레이어가 연결을 끊었는지 확인
+					h.checkAllPlayersDisconnected(room)
+				}
 			}
 
 		case message := <-h.broadcast:
 			h.mu.RLock()
 			for client := range h.clients {
-				select {
-				case client.Send <- message:
-				default:
-					close(client.Send)
-					delete(h.clients, client)
-				}
+				h.send(client, message)
 			}
 			h.mu.RUnlock()
 		}
 	}
 }
 
-// 모든 플레이어가 연결을 끊었는지 확인하고 게임 종료
-func (h *Handler) checkAllPlayersDisconnected() {
-	GlobalRoom.mu.RLock()
-	isGameStarted := GlobalRoom.isGameStarted
-	GlobalRoom.mu.RUnlock()
-
+// 모든 플레이어가 연결을 끊었는지 확인하고, 재접속 대기시간 동안 아무도 돌아오지 않으면 게임 종료
+func (h *Handler) checkAllPlayersDisconnected(room *Room) {
 	// 게임이 시작되지 않았으면 무시
-	if !isGameStarted {
+	if !room.game.IsGameStarted() {
 		return
 	}
 
-	// 연결된 플레이어 수 확인
-	h.mu.RLock()
-	connectedPlayers := 0
-	for client := range h.clients {
-		if client.IsInRoom {
-			connectedPlayers++
-		}
+	// 방에 연결된 플레이어가 남아있으면 무시
+	if room.connectedCount() > 0 {
+		return
 	}
-	h.mu.RUnlock()
 
-	// 모든 플레이어가 연결을 끊었으면 게임 종료
-	if connectedPlayers == 0 {
-		log.Printf("모든 플레이어가 연결을 끊어서 게임 종료")
-
-		GlobalRoom.mu.Lock()
-		// 게임 상태 초기화
-		GlobalRoom.isGameStarted = false
-		GlobalRoom.isCardGameStarted = false
-		GlobalRoom.playerCards = nil
-		GlobalRoom.readyPlayers = nil
-		GlobalRoom.publicFruitIndexes = nil           // 공개된 카드 배열 초기화
-		GlobalRoom.publicFruitCounts = nil            // 공개된 카드 배열 초기화
-		GlobalRoom.bellRung = false                   // 벨 누르기 상태 초기화
-		GlobalRoom.isTimeExpired = false              // 시간제한 상태 초기화
-		GlobalRoom.playerIndexes = nil                // 플레이어 인덱스 매핑 초기화
-		GlobalRoom.players = make(map[string]*Player) // 방 비우기
-
-		// 카드 타이머 정지
-		if GlobalRoom.cardTimer != nil {
-			GlobalRoom.cardTimer.Stop()
-			GlobalRoom.cardTimer = nil
-		}
-		GlobalRoom.mu.Unlock()
+	log.Printf("모든 플레이어가 연결을 끊음 - 방: %s, %d초간 재접속 대기", room.id, config.ReconnectGracePeriod)
 
-		log.Printf("게임 상태 초기화 완료")
+	room.mu.Lock()
+	if room.abandonTimer != nil {
+		room.abandonTimer.Stop()
 	}
+	room.abandonTimer = time.AfterFunc(time.Duration(config.ReconnectGracePeriod)*time.Second, func() {
+		h.abandonRoom(room)
+	})
+	room.mu.Unlock()
 }
 
-// 카드 공개 타이머 시작
-func (h *Handler) startCardTimer() {
-	// 기존 타이머가 있다면 정지
-	if GlobalRoom.cardTimer != nil {
-		GlobalRoom.cardTimer.Stop()
+// 재접속 대기시간이 지나도록 아무도 돌아오지 않은 방의 게임 종료
+func (h *Handler) abandonRoom(room *Room) {
+	room.mu.Lock()
+	// 그 사이 재접속한 플레이어가 있으면 유지
+	if room.connectedCount() > 0 || !room.game.IsGameStarted() {
+		room.mu.Unlock()
+		return
 	}
 
-	// 설정된 간격마다 카드 공개
-	GlobalRoom.cardTimer = time.AfterFunc(time.Duration(config.CardOpenInterval)*time.Second, func() {
-		h.openCard()
-	})
+	log.Printf("모든 플레이어가 연결을 끊어서 게임 종료 - 방: %s", room.id)
+
+	// 게임 상태 초기화 및 타이머 정지
+	room.game.Reset()
+	room.stopTimersLocked()
+	room.mu.Unlock()
+
+	// 관전자들의 관전 상태 해제
+	room.detachSpectators()
+
+	// 빈 방 삭제
+	h.rooms.RemoveRoom(room.id)
+
+	log.Printf("게임 상태 초기화 완료")
 }
 
-// 카드 공개
-func (h *Handler) openCard() {
-	GlobalRoom.mu.Lock()
-	defer GlobalRoom.mu.Unlock()
+// 재접속 처리 (게임 중 연결이 끊긴 좌석을 새 연결에 다시 연결)
+func (h *Handler) handleReconnect(client *Client, reconnectData *RequestReconnectData) {
+	// 이미 방에 참여한 상태인지 확인
+	if client.IsInRoom || client.IsSpectating {
+		h.sendErrorWithSignal(client, RequestReconnect, ErrCodeAlreadyInRoom, "이미 방에 참여한 상태입니다")
+		return
+	}
 
-	// 카드 게임이 시작되지 않았으면 무시
-	if !GlobalRoom.isCardGameStarted {
+	if reconnectData.ResumeToken == client.resumeToken {
+		h.sendErrorFor(client, RequestReconnect, ErrResumeSessionInUse)
 		return
 	}
 
-	// 플레이어가 없으면 무시
-	totalPlayers := len(GlobalRoom.players)
-	if totalPlayers == 0 {
-		log.Printf("플레이어가 없어서 카드 공개 중단")
+	// 끊긴 세션 되찾기
+	session, err := h.sessions.claim(reconnectData.ResumeToken)
+	if err != nil {
+		h.sendErrorFor(client, RequestReconnect, err)
+		return
+	}
+
+	// 좌석이 남아있는 진행 중인 게임인지 확인
+	room := session.room
+	if current, exists := h.rooms.GetRoom(room.id); !exists || current != room || !room.game.IsGameStarted() || !room.game.HasPlayer(session.clientID) {
+		h.sessions.remove(reconnectData.ResumeToken)
+		h.sendErrorWithSignal(client, RequestReconnect, ErrCodeReconnectFailed, "재접속할 게임이 없습니다")
 		return
 	}
 
-	// 랜덤 과일 인덱스 (0-2)
-	fruitIndex := rand.Intn(3)
+	snapshot := room.snapshot(session.clientID)
+	if snapshot.MyIndex < 0 {
+		h.sessions.remove(reconnectData.ResumeToken)
+		h.sendErrorWithSignal(client, RequestReconnect, ErrCodeReconnectFailed, "재접속할 게임이 없습니다")
+		return
+	}
 
-	// 랜덤 과일 개수 (1-5)
-	fruitCount := rand.Intn(5) + 1
+	// 새 연결에 발급했던 토큰은 폐기하고 기존 좌석의 ID와 토큰으로 교체
+	h.sessions.remove(client.resumeToken)
+
+	client.mu.Lock()
+	previousID := client.ID
+	client.ID = session.clientID
+	client.resumeToken = reconnectData.ResumeToken
+	client.AccountID = session.accountID
+	client.Nickname = session.nickname
+	client.sessionToken = session.sessionToken
+	client.IsInRoom = true
+	client.Username = snapshot.PlayerNames[snapshot.MyIndex]
+	client.Room = room
+	client.mu.Unlock()
 
-	// 현재 플레이어 인덱스
-	playerIndex := GlobalRoom.currentPlayerIndex
+	room.game.SetPlayerActive(session.clientID, true)
+	room.addClient(client)
 
-	// 카드를 가진 플레이어를 찾을 때까지 순환
-	originalPlayerIndex := playerIndex
-	for GlobalRoom.playerCards[playerIndex] <= 0 {
-		// 다음 플레이어로 순환
-		GlobalRoom.currentPlayerIndex = (GlobalRoom.currentPlayerIndex + 1) % totalPlayers
-		playerIndex = GlobalRoom.currentPlayerIndex
+	// 재접속을 기다리던 방 정리 타이머 정지
+	room.mu.Lock()
+	if room.abandonTimer != nil {
+		room.abandonTimer.Stop()
+		room.abandonTimer = nil
+	}
+	room.mu.Unlock()
 
-		// 한 바퀴 돌았는데도 카드를 가진 플레이어가 없으면 게임 종료
-		if playerIndex == originalPlayerIndex {
-			log.Printf("모든 플레이어가 카드를 가지고 있지 않아서 게임 종료")
+	// 재접속 성공 응답 (전체 상태 스냅샷 포함)
+	response := NewSuccessResponse(ResponseReconnect, snapshot)
+	h.sendToClient(client, response)
 
-			// 각 플레이어가 공개한 카드를 자신의 손패로 되돌리기
-			GlobalRoom.returnOpenCardsToPlayers()
+	log.Printf("플레이어 재접속: %s -> %s (%s) - 방: %s, 인덱스: %d", previousID, client.ID, client.Username, room.id, snapshot.MyIndex)
+}
 
-			log.Printf("=== openCard에서 endGameInternal 호출 ===")
-			h.endGameInternal()
-			return
-		}
+// 카드 공개 타이머 시작 (뮤텍스가 이미 잠겨있는 상태에서 호출)
+func (h *Handler) startCardTimer(room *Room) {
+	// 기존 타이머가 있다면 정지
+	if room.cardTimer != nil {
+		room.cardTimer.Stop()
 	}
 
-	// 플레이어 손패에서 카드 1장 제거
-	GlobalRoom.playerCards[playerIndex]--
-	GlobalRoom.openCards[playerIndex]++
+	// 설정된 간격마다 카드 공개
+	room.cardTimer = time.AfterFunc(time.Duration(room.gameConfig.CardOpenInterval)*time.Second, func() {
+		h.openCard(room)
+	})
+}
 
-	// 해당 플레이어의 공개된 카드 정보 업데이트
-	GlobalRoom.publicFruitIndexes[playerIndex] = fruitIndex
-	GlobalRoom.publicFruitCounts[playerIndex] = fruitCount
+// 카드 공개
+func (h *Handler) openCard(room *Room) {
+	room.mu.Lock()
+	defer room.mu.Unlock()
 
-	// 다음 플레이어로 순환 (카드를 낸 후)
-	GlobalRoom.currentPlayerIndex = (GlobalRoom.currentPlayerIndex + 1) % totalPlayers
+	// 벨 누르기 판정 중에는 공개된 카드가 바뀌지 않도록 다음 카드 공개를 미룸
+	if room.bellTimer != nil {
+		h.startCardTimer(room)
+		return
+	}
 
-	// 벨 누르기 상태 리셋 (새로운 카드가 공개됨)
-	GlobalRoom.bellRung = false
+	// 현재 차례인 플레이어의 카드 공개
+	opened, err := room.game.OpenCard()
+	switch err {
+	case nil:
+	case game.ErrNoCardsLeft:
+		log.Printf("모든 플레이어가 카드를 가지고 있지 않아서 게임 종료")
+		log.Printf("=== openCard에서 endGameInternal 호출 ===")
+		h.endGameInternal(room)
+		return
+	default:
+		// 카드 게임이 시작되지 않았거나 플레이어가 없으면 무시
+		log.Printf("카드 공개 중단 - 방: %s, 사유: %v", room.id, err)
+		return
+	}
 
 	// 카드 공개 데이터 생성
 	openCardData := &OpenCardData{
-		FruitIndex:  fruitIndex,
-		FruitCount:  fruitCount,
-		PlayerIndex: playerIndex,
+		FruitIndex:  opened.Card.FruitIndex,
+		FruitCount:  opened.Card.FruitCount,
+		PlayerIndex: opened.PlayerIndex,
 	}
 
-	// 모든 클라이언트에게 카드 공개 패킷 전송
-	h.mu.RLock()
-	for client := range h.clients {
-		if client.IsInRoom {
-			response := NewSuccessResponse(ResponseOpenCard, openCardData)
-			h.sendToClient(client, response)
-		}
-	}
-	h.mu.RUnlock()
+	// 방의 모든 클라이언트에게 카드 공개 패킷 전송
+	response := NewSuccessResponse(ResponseOpenCard, openCardData)
+	h.broadcastToRoom(room, response)
+
+	log.Printf("카드 공개: 과일%d, 개수%d, 플레이어%d", opened.Card.FruitIndex, opened.Card.FruitCount, opened.PlayerIndex)
 
-	log.Printf("카드 공개: 과일%d, 개수%d, 플레이어%d", fruitIndex, fruitCount, playerIndex)
+	// 봇들이 공개된 카드에 반응하도록 벨 누르기 예약
+	h.scheduleBotReactions(room, opened)
 
 	// 다음 카드 공개 타이머 설정
-	GlobalRoom.cardTimer = time.AfterFunc(time.Duration(config.CardOpenInterval)*time.Second, func() {
-		h.openCard()
-	})
+	h.startCardTimer(room)
 }
 
-// 벨 누르기 처리
+// 벨 누르기 처리 (받은 시각에서 편도 지연 시간을 빼 누른 시각을 추정하고 판정 구간에 모음)
 func (h *Handler) handleRingBell(client *Client) {
-	// 방에 참여하지 않은 상태인지 확인
-	if !client.IsInRoom {
-		h.sendErrorWithSignal(client, RequestRingBell, "방에 참여하지 않은 상태입니다")
-		return
-	}
+	room := client.Room
 
-	// 게임이 시작되지 않은 상태인지 확인
-	GlobalRoom.mu.RLock()
-	isGameStarted := GlobalRoom.isGameStarted
-	GlobalRoom.mu.RUnlock()
-
-	if !isGameStarted {
-		h.sendErrorWithSignal(client, RequestRingBell, "게임이 시작되지 않은 상태입니다")
-		return
+	press := &bellPress{
+		client:     client,
+		playerID:   client.ID,
+		username:   client.Username,
+		pressedAt:  client.receivedAt.Add(-client.latencyCompensation()),
+		receivedAt: client.receivedAt,
 	}
-
-	// 이미 벨이 눌렸는지 확인
-	GlobalRoom.mu.Lock()
-	if GlobalRoom.bellRung {
-		GlobalRoom.mu.Unlock()
-		log.Printf("플레이어 벨 누름 무시: %s (%s) - 이미 벨이 눌린 상태", client.ID, client.Username)
-		return
+	if err := h.submitBellPress(room, press); err != nil {
+		log.Printf("벨 누르기 실패: %s (%s) - %v", client.ID, client.Username, err)
+		h.sendErrorFor(client, RequestRingBell, err)
 	}
+}
 
-	// 벨 누르기 상태 설정
-	GlobalRoom.bellRung = true
-	GlobalRoom.mu.Unlock()
-
-	// 종을 칠 수 있는 타이밍인지 확인
-	isBellRingingTime := GlobalRoom.IsBellRingingTime()
-
-	// 벨을 누른 플레이어의 인덱스 찾기 (게임 시작 시 설정된 인덱스 사용)
-	GlobalRoom.mu.RLock()
-	playerIndex, exists := GlobalRoom.playerIndexes[client.ID]
-	GlobalRoom.mu.RUnlock()
+// 벨 누르기 판정 후 결과를 방에 브로드캐스트 (뮤텍스가 이미 잠겨있는 상태에서 호출, 봇도 같은 경로 사용)
+func (h *Handler) ringBellLocked(room *Room, press *bellPress) error {
+	playerID, username := press.playerID, press.username
 
-	if !exists {
-		log.Printf("플레이어 인덱스를 찾을 수 없음: %s (%s)", client.ID, client.Username)
-		h.sendErrorWithSignal(client, RequestRingBell, "플레이어 인덱스를 찾을 수 없습니다")
-		return
+	// 벨 누르기 판정 (반응 시간은 지연 보정 없이 서버가 받은 시각 기준)
+	result, err := room.game.RingBell(playerID, press.receivedAt)
+	switch err {
+	case nil:
+	case game.ErrBellAlreadyRung:
+		log.Printf("플레이어 벨 누름 무시: %s (%s) - 이미 벨이 눌린 상태", playerID, username)
+		return nil
+	default:
+		return err
 	}
 
-	log.Printf("플레이어 벨 누름: %s (%s) - 종을 칠 수 있는 타이밍: %v, 플레이어 인덱스: %d", client.ID, client.Username, isBellRingingTime, playerIndex)
+	log.Printf("플레이어 벨 누름: %s (%s) - 종을 칠 수 있는 타이밍: %v, 플레이어 인덱스: %d, 반응 시간: %dms", playerID, username, result.Correct, result.PlayerIndex, result.ReactionMs)
 
 	// OpenCard 타이머 초기화
-	h.resetCardTimer()
+	h.startCardTimer(room)
 
 	// 벨 누르기 결과 처리
-	if isBellRingingTime {
-		// 벨을 올바르게 누른 경우, 공개된 모든 카드를 해당 플레이어의 손패에 추가
-		GlobalRoom.AddAllPublicCardsToPlayer(playerIndex)
-		log.Printf("벨 누르기 성공! 플레이어 %d의 손패에 공개된 모든 카드 추가", playerIndex)
-
-		// 업데이트된 카드 개수 배열 가져오기
-		GlobalRoom.mu.RLock()
-		updatedPlayerCards := make([]int, len(GlobalRoom.playerCards))
-		copy(updatedPlayerCards, GlobalRoom.playerCards)
-		isTimeExpired := GlobalRoom.isTimeExpired
-		GlobalRoom.mu.RUnlock()
-
+	if result.Correct {
 		// 성공 데이터 생성
 		ringBellCorrectData := &RingBellCorrectData{
-			PlayerIndex: playerIndex,
-			PlayerCards: updatedPlayerCards,
+			PlayerIndex: result.PlayerIndex,
+			PlayerCards: result.PlayerCards,
+			ReactionMs:  result.ReactionMs,
 		}
 
-		// 모든 클라이언트에게 성공 결과 전송
-		h.mu.RLock()
-		for c := range h.clients {
-			if c.IsInRoom {
-				response := NewSuccessResponse(ResponseRingBellCorrect, ringBellCorrectData)
-				h.sendToClient(c, response)
-			}
-		}
-		h.mu.RUnlock()
+		// 방의 모든 클라이언트에게 성공 결과 전송
+		response := NewSuccessResponse(ResponseRingBellCorrect, ringBellCorrectData)
+		h.broadcastToRoom(room, response)
 
-		log.Printf("벨 누르기 성공! 플레이어 인덱스: %d", playerIndex)
+		log.Printf("벨 누르기 성공! 플레이어 인덱스: %d", result.PlayerIndex)
 
 		// 시간제한이 끝난 후 올바르게 종을 친 경우 게임 종료
-		if isTimeExpired {
+		if result.GameOver {
 			log.Printf("시간제한 후 올바른 벨 누르기로 게임 종료")
-			h.endGame()
+			h.endGameInternal(room)
 		}
 	} else {
-		// 벨을 잘못 누른 경우, 다른 플레이어들에게 카드 분배
-		cardGivenTo := GlobalRoom.DistributeCardsFromPlayer(playerIndex)
-		log.Printf("벨 누르기 실패! 플레이어 %d가 다른 플레이어들에게 카드 분배", playerIndex)
-
-		// 업데이트된 카드 개수 배열 다시 가져오기
-		GlobalRoom.mu.RLock()
-		updatedPlayerCards := make([]int, len(GlobalRoom.playerCards))
-		copy(updatedPlayerCards, GlobalRoom.playerCards)
-		GlobalRoom.mu.RUnlock()
-
 		// 실패 데이터 생성
 		ringBellWrongData := &RingBellWrongData{
-			PlayerIndex: playerIndex,
-			CardGivenTo: cardGivenTo,
-			PlayerCards: updatedPlayerCards,
+			PlayerIndex: result.PlayerIndex,
+			CardGivenTo: result.CardGivenTo,
+			PlayerCards: result.PlayerCards,
 		}
 
-		// 모든 클라이언트에게 실패 결과 전송
-		h.mu.RLock()
-		for c := range h.clients {
-			if c.IsInRoom {
-				response := NewSuccessResponse(ResponseRingBellWrong, ringBellWrongData)
-				h.sendToClient(c, response)
-			}
-		}
-		h.mu.RUnlock()
+		// 방의 모든 클라이언트에게 실패 결과 전송
+		response := NewSuccessResponse(ResponseRingBellWrong, ringBellWrongData)
+		h.broadcastToRoom(room, response)
 
-		log.Printf("벨 누르기 실패! 플레이어 인덱스: %d", playerIndex)
+		log.Printf("벨 누르기 실패! 플레이어 인덱스: %d", result.PlayerIndex)
 	}
+	return nil
 }
 
 // 감정표현 처리
-func (h *Handler) handleEmotion(client *Client, request *RequestPacket) {
-	// 방에 참여하지 않은 상태인지 확인
-	if !client.IsInRoom {
-		h.sendErrorWithSignal(client, RequestEmotion, "방에 참여하지 않은 상태입니다")
-		return
-	}
-
-	// 게임이 시작되지 않은 상태인지 확인
-	GlobalRoom.mu.RLock()
-	isGameStarted := GlobalRoom.isGameStarted
-	GlobalRoom.mu.RUnlock()
-
-	if !isGameStarted {
-		h.sendErrorWithSignal(client, RequestEmotion, "게임이 시작되지 않은 상태입니다")
-		return
-	}
-
-	// 요청 데이터 파싱
-	var emotionData RequestEmotionData
-
-	// request.Data가 map[string]interface{}인 경우를 처리
-	if dataMap, ok := request.Data.(map[string]interface{}); ok {
-		if emotionType, exists := dataMap["emotionType"]; exists {
-			if emotionTypeFloat, ok := emotionType.(float64); ok {
-				emotionData.EmotionType = int(emotionTypeFloat)
-			} else {
-				log.Printf("감정표현 타입이 숫자가 아님: %v", emotionType)
-				h.sendErrorWithSignal(client, RequestEmotion, "잘못된 감정표현 타입입니다")
-				return
-			}
-		} else {
-			log.Printf("감정표현 데이터에 emotionType이 없음")
-			h.sendErrorWithSignal(client, RequestEmotion, "감정표현 타입이 없습니다")
-			return
-		}
-	} else {
-		log.Printf("감정표현 데이터 형식 오류: %v", request.Data)
-		h.sendErrorWithSignal(client, RequestEmotion, "잘못된 감정표현 데이터 형식입니다")
-		return
-	}
+func (h *Handler) handleEmotion(client *Client, emotionData *RequestEmotionData) {
+	room := client.Room
 
-	// 1초 이내 중복 감정표현 체크
-	GlobalRoom.mu.Lock()
-	lastTime, exists := GlobalRoom.lastEmotionTimes[client.ID]
+	// 제한시간 이내 중복 감정표현 체크
+	room.mu.Lock()
+	lastTime, exists := room.lastEmotionTimes[client.ID]
 	now := time.Now()
 
-	if exists && now.Sub(lastTime) < time.Duration(config.EmotionCooldown)*time.Second {
-		GlobalRoom.mu.Unlock()
-		log.Printf("감정표현 무시: %s (%s) - %d초 이내 중복 감정표현", client.ID, client.Username, config.EmotionCooldown)
+	if exists && now.Sub(lastTime) < time.Duration(room.gameConfig.EmotionCooldown)*time.Second {
+		room.mu.Unlock()
+		log.Printf("감정표현 무시: %s (%s) - %d초 이내 중복 감정표현", client.ID, client.Username, room.gameConfig.EmotionCooldown)
 		return
 	}
 
 	// 마지막 감정표현 시간 업데이트
-	GlobalRoom.lastEmotionTimes[client.ID] = now
-	GlobalRoom.mu.Unlock()
+	room.lastEmotionTimes[client.ID] = now
+	room.mu.Unlock()
 
 	// 플레이어 인덱스 찾기
-	GlobalRoom.mu.RLock()
-	playerIndex, exists := GlobalRoom.playerIndexes[client.ID]
-	GlobalRoom.mu.RUnlock()
+	playerIndex, exists := room.game.PlayerIndex(client.ID)
 
 	if !exists {
 		log.Printf("플레이어 인덱스를 찾을 수 없음: %s (%s)", client.ID, client.Username)
-		h.sendErrorWithSignal(client, RequestEmotion, "플레이어 인덱스를 찾을 수 없습니다")
+		h.sendErrorWithSignal(client, RequestEmotion, ErrCodePlayerNotSeated, "플레이어 인덱스를 찾을 수 없습니다")
 		return
 	}
 
@@ -1178,117 +1451,40 @@ func (h *Handler) handleEmotion(client *Client, request *RequestPacket) {
 		EmotionType: emotionData.EmotionType,
 	}
 
-	// 모든 클라이언트에게 감정표현 패킷 전송
-	h.mu.RLock()
-	for c := range h.clients {
-		if c.IsInRoom {
-			response := NewSuccessResponse(ResponseEmotion, responseEmotionData)
-			h.sendToClient(c, response)
-		}
-	}
-	h.mu.RUnlock()
+	// 방의 모든 클라이언트에게 감정표현 패킷 전송
+	response := NewSuccessResponse(ResponseEmotion, responseEmotionData)
+	h.broadcastToRoom(room, response)
 
 	log.Printf("감정표현 전송 완료 - 플레이어 인덱스: %d, 감정타입: %d", playerIndex, emotionData.EmotionType)
 }
 
 // 계정 생성 처리
-func (h *Handler) handleCreateAccount(client *Client, request *RequestPacket) {
-	// 요청 데이터 파싱
-	var createAccountData RequestCreateAccountData
-
-	// request.Data가 map[string]interface{}인 경우를 처리
-	if dataMap, ok := request.Data.(map[string]interface{}); ok {
-		// ID 확인
-		if id, exists := dataMap["id"]; exists {
-			if idStr, ok := id.(string); ok {
-				createAccountData.ID = idStr
-			} else {
-				log.Printf("계정 생성 ID가 문자열이 아님: %v", id)
-				h.sendErrorWithSignal(client, RequestCreateAccount, "잘못된 ID 형식입니다")
-				return
-			}
-		} else {
-			log.Printf("계정 생성 데이터에 ID가 없음")
-			h.sendErrorWithSignal(client, RequestCreateAccount, "ID가 없습니다")
-			return
-		}
-
-		// Password 확인
-		if password, exists := dataMap["password"]; exists {
-			if passwordStr, ok := password.(string); ok {
-				createAccountData.Password = passwordStr
-			} else {
-				log.Printf("계정 생성 Password가 문자열이 아님: %v", password)
-				h.sendErrorWithSignal(client, RequestCreateAccount, "잘못된 Password 형식입니다")
-				return
-			}
-		} else {
-			log.Printf("계정 생성 데이터에 Password가 없음")
-			h.sendErrorWithSignal(client, RequestCreateAccount, "Password가 없습니다")
-			return
-		}
-
-		// Nickname 확인
-		if nickname, exists := dataMap["nickname"]; exists {
-			if nicknameStr, ok := nickname.(string); ok {
-				createAccountData.Nickname = nicknameStr
-			} else {
-				log.Printf("계정 생성 Nickname이 문자열이 아님: %v", nickname)
-				h.sendErrorWithSignal(client, RequestCreateAccount, "잘못된 Nickname 형식입니다")
-				return
-			}
-		} else {
-			log.Printf("계정 생성 데이터에 Nickname이 없음")
-			h.sendErrorWithSignal(client, RequestCreateAccount, "Nickname이 없습니다")
-			return
-		}
-	} else {
-		log.Printf("계정 생성 데이터 형식 오류: %v", request.Data)
-		h.sendErrorWithSignal(client, RequestCreateAccount, "잘못된 계정 생성 데이터 형식입니다")
-		return
-	}
-
-	// 데이터 유효성 검사
-	if createAccountData.ID == "" {
-		h.sendErrorWithSignal(client, RequestCreateAccount, "ID는 비어있을 수 없습니다")
-		return
-	}
-	if len(createAccountData.ID) > 10 {
-		h.sendErrorWithSignal(client, RequestCreateAccount, "ID는 10자를 넘을 수 없습니다")
-		return
-	}
-	if createAccountData.Password == "" {
-		h.sendErrorWithSignal(client, RequestCreateAccount, "Password는 비어있을 수 없습니다")
-		return
-	}
-	if len(createAccountData.Password) > 10 {
-		h.sendErrorWithSignal(client, RequestCreateAccount, "Password는 10자를 넘을 수 없습니다")
-		return
-	}
-	if createAccountData.Nickname == "" {
-		h.sendErrorWithSignal(client, RequestCreateAccount, "Nickname은 비어있을 수 없습니다")
-		return
-	}
-	if len(createAccountData.Nickname) > 10 {
-		h.sendErrorWithSignal(client, RequestCreateAccount, "Nickname은 10자를 넘을 수 없습니다")
+func (h *Handler) handleCreateAccount(client *Client, createAccountData *RequestCreateAccountData) {
+	// 닉네임 금칙어 검사
+	if err := h.validateNickname(createAccountData.Nickname); err != nil {
+		h.sendErrorFor(client, RequestCreateAccount, err)
 		return
 	}
 
 	log.Printf("계정 생성 요청: ID=%s, Nickname=%s", createAccountData.ID, createAccountData.Nickname)
-  
+
 	// ▶ 비밀번호 해싱
 	hashedPassword, err := utils.HashPassword(createAccountData.Password)
 	if err != nil {
 		log.Printf("비밀번호 해싱 실패: %v", err)
-		h.sendErrorWithSignal(client, RequestCreateAccount, "서버 오류로 계정 생성에 실패했습니다")
+		h.sendErrorWithSignal(client, RequestCreateAccount, ErrCodeServerError, "서버 오류로 계정 생성에 실패했습니다")
 		return
 	}
 	createAccountData.Password = hashedPassword
 
 	// DB에 계정 정보 저장
-	if err := h.saveAccountToDB(createAccountData); err != nil {
+	if err := h.saveAccountToDB(*createAccountData); err != nil {
 		log.Printf("계정 생성 실패: ID=%s, 오류=%v", createAccountData.ID, err)
-		h.sendErrorWithSignal(client, RequestCreateAccount, "계정 생성에 실패했습니다")
+		if errors.Is(err, ErrDuplicateID) || errors.Is(err, ErrDuplicateNickname) {
+			h.sendErrorFor(client, RequestCreateAccount, err)
+			return
+		}
+		h.sendErrorWithSignal(client, RequestCreateAccount, ErrCodeServerError, "계정 생성에 실패했습니다")
 		return
 	}
 
@@ -1310,320 +1506,311 @@ func (h *Handler) saveAccountToDB(accountData RequestCreateAccountData) error {
 	err := db.DB.QueryRow("SELECT id FROM Users WHERE id = $1", accountData.ID).Scan(&existingID)
 	if err == nil {
 		// 이미 존재하는 ID
-		return fmt.Errorf("이미 존재하는 ID입니다")
+		return ErrDuplicateID
 	} else if err != sql.ErrNoRows {
 		// DB 오류
 		return fmt.Errorf("DB 조회 오류: %v", err)
 	}
 
-	// ▶ 새 계정 저장: 비밀번호 대신 해시된 값을 사용
-	_, err = db.DB.Exec(
-		"INSERT INTO Users (id, password, nickname) VALUES ($1, $2, $3)",
-		accountData.ID, accountData.Password, accountData.Nickname,
-	)
+	// ▶ 새 계정 저장: 비밀번호 대신 해시된 값을 사용 (닉네임 중복은 닉네임 변경과 같은 방식으로 저장할 때 검사)
+	result, err := db.DB.Exec(`
+        INSERT INTO Users (id, password, nickname)
+        SELECT $1, $2, $3
+        WHERE NOT EXISTS (SELECT 1 FROM Users WHERE nickname = $3)
+    `, accountData.ID, accountData.Password, accountData.Nickname)
 	if err != nil {
 		return fmt.Errorf("계정 저장 오류: %v", err)
 	}
 
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("계정 저장 결과 조회 오류: %v", err)
+	}
+	if inserted == 0 {
+		return ErrDuplicateNickname
+	}
 	return nil
 }
 
-// 로그인 처리 핸들러
-func (h *Handler) handleLogin(client *Client, request *RequestPacket) {
-	// 데이터 파싱
-	dataMap, ok := request.Data.(map[string]interface{})
-	if !ok {
-		h.sendErrorWithSignal(client, RequestLogin, "잘못된 로그인 데이터 형식입니다")
-		return	
-	}
-	if idVal == "" || pwVal == "" {
-		h.sendErrorWithSignal(client, RequestLogin, "ID와 Password는 비어있을 수 없습니다.")
-		return
+// 닉네임 금칙어 검사 (계정 생성과 닉네임 변경에서 함께 사용, 길이는 요청 데이터 검증에서 확인)
+func (h *Handler) validateNickname(nickname string) error {
+	if h.profanityFilter.Contains(nickname) {
+		return ErrBannedNickname
 	}
+	return nil
+}
 
-	// DB에서 해시된 비밀번호 조회
-	var storedHashedPassword string
-	err := db.DB.QueryRow("SELECT password FROM Users WHERE id = $1", idVal).Scan(&storedHashedPassword)
-
-	if err == sql.ErrNoRows {
-		// ID가 존재하지 않는 경우
-		h.sendErrorWithSignal(client, RequestLogin, "존재하지 않는 ID입니다.")
+// 닉네임 변경 처리 핸들러
+func (h *Handler) handleChangeNickName(client *Client, changeData *RequestChangeNickNameData) {
+	// 데이터 유효성 검사
+	if err := h.validateNickname(changeData.Nickname); err != nil {
+		h.sendErrorFor(client, RequestChangeNickName, err)
 		return
-	} else if err != nil {
-		h.sendErrorWithSignal(client, RequestLogin, "서버 오류로 로그인에 실패했습니다.")
+	}
+	if changeData.Nickname == client.Nickname {
+		h.sendErrorWithSignal(client, RequestChangeNickName, ErrCodeSameNickname, "현재 Nickname과 같습니다")
 		return
 	}
 
-	// 비밀번호 검증
-	if !utils.CheckPasswordHash(pwVal, storedHashedPassword) {
-		h.sendErrorWithSignal(client, RequestLogin, "잘못된 비밀번호입니다.")
+	// DB에 새 닉네임 저장 (다른 계정이 쓰는 닉네임이면 거부)
+	if err := h.updateNicknameInDB(client.AccountID, changeData.Nickname); err != nil {
+		log.Printf("닉네임 변경 실패: ID=%s, 오류=%v", client.AccountID, err)
+		h.sendErrorFor(client, RequestChangeNickName, err)
 		return
 	}
 
-	var nickname string
-	err = db.DB.QueryRow("SELECT nickname FROM Users WHERE id = $1", idVal).Scan(&nickname)
-	if err != nil {	
-		log.Printf("닉네임 조회 오류: %v", err)
-		nickname = "Unknown" // 기본값 설정
-	}
+	previousNickname := client.Nickname
+	client.mu.Lock()
+	client.Nickname = changeData.Nickname
+	client.mu.Unlock()
 
-	// 성공 패킷 생성
-	responseData := &ResponseLoginData{
-		Nickname: nickname
+	log.Printf("닉네임 변경: ID=%s, %s -> %s", client.AccountID, previousNickname, changeData.Nickname)
+
+	responseData := &ChangeNickNameData{
+		PlayerID:    client.ID,
+		Nickname:    changeData.Nickname,
+		PlayerIndex: -1,
+		PlayerNames: []string{},
 	}
-	response := NewSuccessResponse(ResponseLogin, responseData)	
-	h.sendToClient(client, response)
 
-	log.Printf("로그인 성공: ID=%s, Nickname=%s", idVal, nickname)
+	// 방에 앉아 있으면 플레이어 이름을 바꾸고 방 전체에 알림
+	room := client.Room
+	if client.IsInRoom && room != nil && room.game.RenamePlayer(client.ID, changeData.Nickname) {
+		client.mu.Lock()
+		client.Username = changeData.Nickname
+		client.mu.Unlock()
+		if playerIndex, seated := room.game.PlayerIndex(client.ID); seated {
+			responseData.PlayerIndex = playerIndex
+			responseData.PlayerNames = room.game.Snapshot().PlayerNames
+		}
+		response := NewSuccessResponse(ResponseChangeNickName, responseData)
+		h.broadcastToRoom(room, response)
+		return
+	}
 
+	response := NewSuccessResponse(ResponseChangeNickName, responseData)
+	h.sendToClient(client, response)
 }
 
-// 공개된 모든 카드를 특정 플레이어의 손패에 추가
-func (r *Room) AddAllPublicCardsToPlayer(playerIndex int) {
-	r.mu.Lock()
-	defer r.mu.Unlock()
-
-	totalCards := 0
-	for i := 0; i < len(r.openCards); i++ {
-		totalCards += r.openCards[i]
+// DB의 계정 닉네임 변경 (같은 닉네임을 쓰는 다른 계정이 있으면 에러)
+func (h *Handler) updateNicknameInDB(accountID, nickname string) error {
+	result, err := db.DB.Exec(`
+        UPDATE Users SET nickname = $1
+        WHERE id = $2 AND NOT EXISTS (SELECT 1 FROM Users WHERE nickname = $1 AND id <> $2)
+    `, nickname, accountID)
+	if err != nil {
+		log.Printf("닉네임 저장 오류: %v", err)
+		return fmt.Errorf("서버 오류로 닉네임 변경에 실패했습니다")
 	}
 
-	// 현재 플레이어의 카드 개수에 추가
-	if playerIndex < len(r.playerCards) {
-		r.playerCards[playerIndex] += totalCards
-		log.Printf("플레이어 %d의 손패에 공개된 모든 카드 %d장 추가", playerIndex, totalCards)
+	updated, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("닉네임 저장 결과 조회 오류: %v", err)
+		return fmt.Errorf("서버 오류로 닉네임 변경에 실패했습니다")
 	}
-
-	// 공개된 카드 정보 초기화
-	for i := 0; i < len(r.publicFruitIndexes); i++ {
-		r.publicFruitIndexes[i] = -1
-		r.publicFruitCounts[i] = -1
-		r.openCards[i] = 0
+	if updated == 0 {
+		return ErrDuplicateNickname
 	}
+	return nil
 }
 
-// 벨을 잘못 친 플레이어가 다른 플레이어들에게 카드를 나누어주는 함수
-func (r *Room) DistributeCardsFromPlayer(playerIndex int) []bool {
-	r.mu.Lock()
-	defer r.mu.Unlock()
-
-	totalPlayers := len(r.playerCards)
-	if totalPlayers == 0 || playerIndex >= totalPlayers {
-		return make([]bool, totalPlayers)
+// 로그인 처리 핸들러
+func (h *Handler) handleLogin(client *Client, loginData *RequestLoginData) {
+	// 이미 로그인했거나 방에 참여한 상태인지 확인
+	if client.AccountID != "" {
+		h.sendErrorWithSignal(client, RequestLogin, ErrCodeAlreadyLoggedIn, "이미 로그인한 상태입니다")
+		return
 	}
-
-	// 카드를 받을 플레이어들 (벨을 친 플레이어 제외)
-	receivers := make([]int, 0)
-	for i := 0; i < totalPlayers; i++ {
-		if i != playerIndex {
-			receivers = append(receivers, i)
-		}
+	if client.IsInRoom || client.IsSpectating {
+		h.sendErrorWithSignal(client, RequestLogin, ErrCodeInRoom, "방에 참여한 상태에서는 로그인할 수 없습니다")
+		return
 	}
 
-	// 벨을 친 플레이어가 가진 카드 수
-	availableCards := r.playerCards[playerIndex]
+	// DB에서 해시된 비밀번호와 닉네임 조회
+	var storedHashedPassword, nickname string
+	err := db.DB.QueryRow("SELECT password, nickname FROM Users WHERE id = $1", loginData.ID).Scan(&storedHashedPassword, &nickname)
+	if err == sql.ErrNoRows {
+		// ID가 존재하지 않는 경우
+		h.sendErrorWithSignal(client, RequestLogin, ErrCodeAuthFailed, "존재하지 않는 ID입니다")
+		return
+	} else if err != nil {
+		log.Printf("로그인 DB 조회 오류: ID=%s, 오류=%v", loginData.ID, err)
+		h.sendErrorWithSignal(client, RequestLogin, ErrCodeServerError, "서버 오류로 로그인에 실패했습니다")
+		return
+	}
 
-	// 카드가 부족한 경우, 랜덤하게 선택된 플레이어들에게만 나누어줌
-	if availableCards < len(receivers) {
-		shuffleIntSlice(receivers)
-		receivers = receivers[:availableCards]
+	// 비밀번호 검증
+	if !utils.CheckPasswordHash(loginData.Password, storedHashedPassword) {
+		h.sendErrorWithSignal(client, RequestLogin, ErrCodeAuthFailed, "잘못된 비밀번호입니다")
+		return
 	}
 
-	// 카드 분배 실행
-	cardGivenTo := make([]bool, totalPlayers)
-	for _, receiverIndex := range receivers {
-		if r.playerCards[playerIndex] > 0 {
-			r.playerCards[playerIndex]--
-			r.playerCards[receiverIndex]++
-			cardGivenTo[receiverIndex] = true
-			log.Printf("플레이어 %d가 플레이어 %d에게 카드 1장 전달", playerIndex, receiverIndex)
-		}
+	// 다음 연결에서 로그인 상태를 복원할 세션 토큰 발급
+	sessionToken, err := auth.IssueSessionToken(loginData.ID)
+	if err != nil {
+		log.Printf("세션 토큰 발급 실패: ID=%s, 오류=%v", loginData.ID, err)
+		h.sendErrorWithSignal(client, RequestLogin, ErrCodeServerError, "서버 오류로 로그인에 실패했습니다")
+		return
 	}
 
-	return cardGivenTo
-}
+	// 로그인한 계정을 연결에 연결
+	h.bindAccount(client, loginData.ID, nickname, sessionToken)
 
-// int 슬라이스를 섞는 함수
-func shuffleIntSlice(slice []int) {
-	for i := len(slice) - 1; i > 0; i-- {
-		j := rand.Intn(i + 1)
-		slice[i], slice[j] = slice[j], slice[i]
+	// 로그인 성공 응답
+	responseData := &ResponseLoginData{
+		ID:           loginData.ID,
+		Nickname:     nickname,
+		SessionToken: sessionToken,
 	}
-}
+	response := NewSuccessResponse(ResponseLogin, responseData)
+	h.sendToClient(client, response)
 
-// string 슬라이스를 섞는 함수
-func shuffleStringSlice(slice []string) {
-	for i := len(slice) - 1; i > 0; i-- {
-		j := rand.Intn(i + 1)
-		slice[i], slice[j] = slice[j], slice[i]
-	}
+	log.Printf("로그인 성공: ID=%s, Nickname=%s (클라이언트: %s)", loginData.ID, nickname, client.ID)
 }
 
-// 공개된 카드를 각 플레이어의 손패로 되돌리는 함수
-func (r *Room) returnOpenCardsToPlayers() {
-	for i := 0; i < len(r.playerCards); i++ {
-		if r.openCards[i] > 0 {
-			r.playerCards[i] += r.openCards[i]
-			log.Printf("플레이어 %d의 공개된 카드 %d장을 손패로 되돌림", i, r.openCards[i])
-		}
+// 세션 토큰 로그인 처리 (새 연결의 첫 패킷으로 로그인 상태 복원)
+func (h *Handler) handleSessionLogin(client *Client, sessionLoginData *RequestSessionLoginData) {
+	// 이미 로그인했거나 방에 참여한 상태인지 확인
+	if client.AccountID != "" {
+		h.sendErrorWithSignal(client, RequestSessionLogin, ErrCodeAlreadyLoggedIn, "이미 로그인한 상태입니다")
+		return
+	}
+	if client.IsInRoom || client.IsSpectating {
+		h.sendErrorWithSignal(client, RequestSessionLogin, ErrCodeInRoom, "방에 참여한 상태에서는 로그인할 수 없습니다")
+		return
 	}
 
-	// 공개된 카드 개수 초기화
-	for i := 0; i < len(r.openCards); i++ {
-		r.openCards[i] = 0
+	responseData, err := h.restoreSession(client, sessionLoginData.SessionToken)
+	if err != nil {
+		h.sendErrorFor(client, RequestSessionLogin, err)
+		return
 	}
-	log.Printf("모든 플레이어의 공개된 카드 개수를 0으로 초기화")
+
+	response := NewSuccessResponse(ResponseSessionLogin, responseData)
+	h.sendToClient(client, response)
 }
 
-// 순위 계산 함수
-func calculatePlayerRanks(playerCards []int) []int {
-	// 플레이어 인덱스와 카드 개수를 함께 저장
-	type PlayerCardInfo struct {
-		playerIndex int
-		cardCount   int
+// 로그아웃 처리 (세션 토큰을 서버에서 폐기)
+func (h *Handler) handleLogout(client *Client) {
+	if client.IsInRoom {
+		h.sendErrorWithSignal(client, RequestLogout, ErrCodeInRoom, "방에 참여한 상태에서는 로그아웃할 수 없습니다")
+		return
 	}
 
-	// 플레이어 정보 배열 생성
-	playerInfos := make([]PlayerCardInfo, len(playerCards))
-	for i, cardCount := range playerCards {
-		playerInfos[i] = PlayerCardInfo{
-			playerIndex: i,
-			cardCount:   cardCount,
-		}
+	if err := auth.RevokeSessionToken(client.sessionToken); err != nil {
+		log.Printf("세션 토큰 폐기 실패: ID=%s, 오류=%v", client.AccountID, err)
+		h.sendErrorWithSignal(client, RequestLogout, ErrCodeServerError, "서버 오류로 로그아웃에 실패했습니다")
+		return
 	}
 
-	// 카드 개수 기준으로 내림차순 정렬 (카드가 많을수록 높은 순위)
-	sort.Slice(playerInfos, func(i, j int) bool {
-		return playerInfos[i].cardCount > playerInfos[j].cardCount
-	})
+	accountID := client.AccountID
+	h.bindAccount(client, "", "", "")
 
-	// 순위 배열 생성 (1등부터 시작)
-	ranks := make([]int, len(playerCards))
-	for i := range ranks {
-		ranks[i] = i + 1 // 기본값으로 인덱스+1 설정
-	}
+	response := NewSuccessResponse(ResponseLogout, map[string]interface{}{})
+	h.sendToClient(client, response)
 
-	// 실제 순위로 업데이트 (공동 순위 처리)
-	currentRank := 1
-	currentCardCount := -1
+	log.Printf("로그아웃: ID=%s (클라이언트: %s)", accountID, client.ID)
+}
 
-	for i, playerInfo := range playerInfos {
-		// 카드 개수가 바뀌면 순위 증가
-		if playerInfo.cardCount != currentCardCount {
-			currentRank = i + 1
-			currentCardCount = playerInfo.cardCount
-		}
+// 세션 토큰을 검증하고 계정을 연결에 복원 (닉네임은 DB의 현재 값 사용)
+func (h *Handler) restoreSession(client *Client, sessionToken string) (*ResponseLoginData, error) {
+	claims, err := auth.VerifySessionToken(sessionToken)
+	if err != nil {
+		log.Printf("세션 토큰 검증 실패: %v", err)
+		return nil, err
+	}
 
-		// 현재 순위를 해당 플레이어에게 할당
-		ranks[playerInfo.playerIndex] = currentRank
+	var nickname string
+	err = db.DB.QueryRow("SELECT nickname FROM Users WHERE id = $1", claims.AccountID).Scan(&nickname)
+	if err == sql.ErrNoRows {
+		return nil, auth.ErrInvalidSessionToken
+	} else if err != nil {
+		log.Printf("세션 계정 조회 오류: ID=%s, 오류=%v", claims.AccountID, err)
+		return nil, fmt.Errorf("서버 오류로 로그인에 실패했습니다")
 	}
 
-	return ranks
+	h.bindAccount(client, claims.AccountID, nickname, sessionToken)
+	log.Printf("세션 토큰으로 로그인 복원: ID=%s, Nickname=%s (클라이언트: %s)", claims.AccountID, nickname, client.ID)
+
+	return &ResponseLoginData{
+		ID:           claims.AccountID,
+		Nickname:     nickname,
+		SessionToken: sessionToken,
+	}, nil
+}
+
+// 로그인한 계정을 연결에 연결 (빈 값이면 로그아웃 상태)
+func (h *Handler) bindAccount(client *Client, accountID, nickname, sessionToken string) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	client.AccountID = accountID
+	client.Nickname = nickname
+	client.sessionToken = sessionToken
 }
 
 // 게임 종료 처리 (뮤텍스가 이미 잠겨있는 경우를 위한 내부 함수)
-func (h *Handler) endGameInternal() {
+func (h *Handler) endGameInternal(room *Room) {
 	log.Printf("=== 게임 종료 함수 호출됨 ===")
-	log.Printf("게임 제한시간 종료 - 게임 종료")
 
-	// 각 플레이어가 공개한 카드를 자신의 손패로 되돌리기
-	GlobalRoom.returnOpenCardsToPlayers()
-
-	// 현재 플레이어 카드 개수와 순위 계산
-	playerCards := make([]int, len(GlobalRoom.playerCards))
-	copy(playerCards, GlobalRoom.playerCards)
-	playerRanks := calculatePlayerRanks(playerCards)
+	// 공개된 카드를 손패로 되돌리고 순위 계산 (이미 종료된 게임이면 무시)
+	result, err := room.game.Finish()
+	if err != nil {
+		return
+	}
 
 	// 게임 종료 데이터 생성
 	endGameData := &EndGameData{
-		PlayerCards: playerCards,
-		PlayerRanks: playerRanks,
+		PlayerCards: result.PlayerCards,
+		PlayerRanks: result.PlayerRanks,
 	}
 
-	// 모든 클라이언트에게 게임 종료 패킷 전송
-	h.mu.RLock()
-	for c := range h.clients {
-		if c.IsInRoom {
-			response := NewSuccessResponse(ResponseEndGame, endGameData)
-			h.sendToClient(c, response)
-		}
-	}
-	h.mu.RUnlock()
+	// 방의 모든 클라이언트에게 게임 종료 패킷 전송
+	response := NewSuccessResponse(ResponseEndGame, endGameData)
+	h.broadcastToRoom(room, response)
 
-	// 게임 상태 초기화
-	GlobalRoom.isGameStarted = false
-	GlobalRoom.isCardGameStarted = false
-	GlobalRoom.playerCards = nil
-	GlobalRoom.readyPlayers = nil
-	GlobalRoom.publicFruitIndexes = nil
-	GlobalRoom.publicFruitCounts = nil
-	GlobalRoom.openCards = nil
-	GlobalRoom.bellRung = false
-	GlobalRoom.isTimeExpired = false
-	GlobalRoom.playerIndexes = nil
-	GlobalRoom.players = make(map[string]*Player)
-	GlobalRoom.lastEmotionTimes = make(map[string]time.Time)
-
-	// 모든 클라이언트의 방 참여 상태 초기화
-	h.mu.RLock()
-	for c := range h.clients {
+	// 게임 기록을 DB에 저장 (방 뮤텍스를 잡은 채로 DB를 기다리지 않도록 별도 고루틴에서)
+	go saveMatch(newMatchRecord(room, result, time.Now()))
+
+	// 감정표현 상태 초기화 및 타이머들 정지
+	room.lastEmotionTimes = make(map[string]time.Time)
+	room.stopTimersLocked()
+
+	// 방에 참여한 클라이언트들의 방 참여 상태와 관전 상태 초기화
+	for _, c := range room.memberClients() {
+		room.removeClient(c)
+		c.mu.Lock()
 		c.IsInRoom = false
+		c.Room = nil
+		c.mu.Unlock()
 	}
-	h.mu.RUnlock()
+	room.detachSpectators()
 
-	// 타이머들 정지
-	if GlobalRoom.cardTimer != nil {
-		GlobalRoom.cardTimer.Stop()
-		GlobalRoom.cardTimer = nil
-	}
-	if GlobalRoom.gameTimer != nil {
-		GlobalRoom.gameTimer.Stop()
-		GlobalRoom.gameTimer = nil
-	}
+	// 게임이 끝난 방 삭제
+	h.rooms.RemoveRoom(room.id)
 
-	log.Printf("게임 종료 완료 - 순위: %v", playerRanks)
+	log.Printf("게임 종료 완료 - 방: %s, 순위: %v", room.id, result.PlayerRanks)
 }
 
 // 게임 종료 처리 (외부에서 호출되는 함수)
-func (h *Handler) endGame() {
-	GlobalRoom.mu.Lock()
-	defer GlobalRoom.mu.Unlock()
-	h.endGameInternal()
+func (h *Handler) endGame(room *Room) {
+	room.mu.Lock()
+	defer room.mu.Unlock()
+	h.endGameInternal(room)
 }
 
-// 게임 타이머 시작
-func (h *Handler) startGameTimer() {
+// 게임 타이머 시작 (뮤텍스가 이미 잠겨있는 상태에서 호출)
+func (h *Handler) startGameTimer(room *Room) {
 	// 기존 게임 타이머가 있다면 정지
-	if GlobalRoom.gameTimer != nil {
-		GlobalRoom.gameTimer.Stop()
+	if room.gameTimer != nil {
+		room.gameTimer.Stop()
 	}
 
 	// 설정된 제한시간 후 시간제한 플래그 설정
-	GlobalRoom.gameTimer = time.AfterFunc(time.Duration(config.GameTimeLimit)*time.Second, func() {
-		GlobalRoom.mu.Lock()
-		GlobalRoom.isTimeExpired = true
-		GlobalRoom.mu.Unlock()
+	room.gameEndsAt = time.Now().Add(time.Duration(room.gameConfig.GameTimeLimit) * time.Second)
+	room.gameTimer = time.AfterFunc(time.Duration(room.gameConfig.GameTimeLimit)*time.Second, func() {
+		room.game.ExpireTime()
 		log.Printf("게임 제한시간 종료 - 누군가가 올바르게 종을 칠 때까지 게임 계속 진행")
 	})
 
-	log.Printf("게임 타이머 시작 - %d초 후 시간제한", config.GameTimeLimit)
-}
-
-// OpenCard 타이머 초기화
-func (h *Handler) resetCardTimer() {
-	GlobalRoom.mu.Lock()
-	defer GlobalRoom.mu.Unlock()
-
-	// 기존 타이머가 있다면 정지
-	if GlobalRoom.cardTimer != nil {
-		GlobalRoom.cardTimer.Stop()
-		GlobalRoom.cardTimer = nil
-	}
-
-	// 새로운 타이머 시작 (설정된 간격 후)
-	GlobalRoom.cardTimer = time.AfterFunc(time.Duration(config.CardOpenInterval)*time.Second, func() {
-		h.openCard()
-	})
-
-	log.Printf("OpenCard 타이머 초기화 완료")
+	log.Printf("게임 타이머 시작 - %d초 후 시간제한", room.gameConfig.GameTimeLimit)
 }
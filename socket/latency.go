@@ -0,0 +1,150 @@
+package socket
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// 보낼 핑을 기록하고 페이로드 생성 (보낸 시각, 유닉스 나노초)
+// 새 핑을 보내면 이전 핑의 퐁은 더 이상 받지 않음
+func (c *Client) nextPing(now time.Time) []byte {
+	c.mu.Lock()
+	c.pingSentAt = now
+	c.mu.Unlock()
+	return []byte(strconv.FormatInt(now.UnixNano(), 10))
+}
+
+// 퐁으로 돌아온 핑 페이로드로 왕복 지연 시간과 흔들림 갱신 (측정값이 튀지 않도록 이전 값과 섞음)
+// 페이로드가 기다리던 핑의 보낸 시각이 아니면 false (클라이언트가 임의로 보낸 퐁으로 지연 시간을 늘릴 수 없음)
+func (c *Client) recordPong(appData string, now time.Time) (time.Duration, time.Duration, bool) {
+	sentAt, err := strconv.ParseInt(appData, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.pingSentAt.IsZero() || sentAt != c.pingSentAt.UnixNano() {
+		return 0, 0, false
+	}
+	sample := now.Sub(c.pingSentAt)
+	c.pingSentAt = time.Time{}
+	if sample < 0 {
+		return 0, 0, false
+	}
+
+	if c.rtt == 0 {
+		c.rtt = sample
+		c.jitter = sample / 2
+	} else {
+		deviation := c.rtt - sample
+		if deviation < 0 {
+			deviation = -deviation
+		}
+		c.jitter = (c.jitter*3 + deviation) / 4
+		c.rtt = (c.rtt*7 + sample) / 8
+	}
+	return c.rtt, c.jitter, true
+}
+
+// 측정한 왕복 지연 시간 (측정 전에는 0)
+func (c *Client) roundTripTime() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rtt
+}
+
+// 측정한 왕복 지연 시간과 흔들림 (측정 전에는 0)
+func (c *Client) latencyStats() (time.Duration, time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rtt, c.jitter
+}
+
+// 퐁 처리 (왕복 지연 시간을 갱신하고 기준을 넘으면 경고하거나 연결 종료)
+func (h *Handler) handlePong(client *Client, appData string) {
+	rtt, jitter, ok := client.recordPong(appData, time.Now())
+	if !ok {
+		return
+	}
+
+	cfg := h.latency
+	overWarn := rtt > time.Duration(cfg.WarnMs)*time.Millisecond
+	overDrop := cfg.DropMs > 0 && rtt > time.Duration(cfg.DropMs)*time.Millisecond
+
+	client.mu.Lock()
+	if overDrop {
+		client.latencyStrikes++
+	} else {
+		client.latencyStrikes = 0
+	}
+	strikes := client.latencyStrikes
+	notify := overWarn && !client.latencyWarned
+	client.latencyWarned = overWarn
+	client.mu.Unlock()
+
+	// 연결 종료 기준을 연속으로 넘으면 안내 후 연결 종료
+	if strikes == cfg.DropAfter {
+		message := fmt.Sprintf("지연 시간이 너무 길어 연결을 종료합니다 (왕복 지연 시간: %dms, 기준: %dms)", rtt.Milliseconds(), cfg.DropMs)
+		h.sendError(client, ErrCodeHighLatency, message)
+		log.Printf("지연 시간 초과로 연결 종료: %s - 왕복 지연 시간: %dms, 흔들림: %dms", client.ID, rtt.Milliseconds(), jitter.Milliseconds())
+
+		// 더 이상 요청을 처리하지 않고 연결 해제 (writePump가 에러 패킷을 마저 보낸 뒤 연결을 닫음)
+		client.disconnect()
+		return
+	}
+
+	// 경고 기준을 처음 넘었을 때만 경고 (기준 아래로 내려갔다가 다시 넘으면 다시 경고)
+	if notify {
+		log.Printf("지연 시간 경고: %s - 왕복 지연 시간: %dms, 흔들림: %dms", client.ID, rtt.Milliseconds(), jitter.Milliseconds())
+
+		response := NewSuccessResponse(ResponseLatencyWarning, &LatencyWarningData{
+			RttMs:    rtt.Milliseconds(),
+			JitterMs: jitter.Milliseconds(),
+			WarnMs:   cfg.WarnMs,
+			DropMs:   cfg.DropMs,
+		})
+		h.sendToClient(client, response)
+	}
+}
+
+// 방 지연 시간 알림 루프 (핑 간격마다 모든 방에 플레이어들의 지연 시간 전송)
+func (h *Handler) runLatencyReports() {
+	ticker := time.NewTicker(time.Duration(h.latency.PingIntervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		for _, room := range h.rooms.Rooms() {
+			if room.connectedCount() == 0 {
+				continue
+			}
+			response := NewSuccessResponse(ResponseRoomLatency, room.latency())
+			h.broadcastToRoom(room, response)
+		}
+	}
+}
+
+// 방에 연결된 플레이어들의 지연 시간 (좌석 순서)
+func (r *Room) latency() *RoomLatencyData {
+	players := []PlayerLatencyData{}
+	for _, client := range r.memberClients() {
+		playerIndex, seated := r.game.PlayerIndex(client.ID)
+		if !seated {
+			continue
+		}
+		rtt, jitter := client.latencyStats()
+		players = append(players, PlayerLatencyData{
+			PlayerIndex: playerIndex,
+			RttMs:       rtt.Milliseconds(),
+			JitterMs:    jitter.Milliseconds(),
+		})
+	}
+
+	sort.Slice(players, func(i, j int) bool {
+		return players[i].PlayerIndex < players[j].PlayerIndex
+	})
+	return &RoomLatencyData{Players: players}
+}
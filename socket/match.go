@@ -0,0 +1,231 @@
+package socket
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"main/config"
+	"main/db"
+	"main/game"
+	"main/leaderboard"
+)
+
+// 끝난 게임 기록 (matches 테이블)
+type matchRecord struct {
+	id        string
+	roomID    string
+	startedAt time.Time
+	endedAt   time.Time
+	config    config.GameConfig
+	players   []matchPlayerRecord // 좌석 순서대로
+}
+
+// 게임에 참여한 플레이어 기록 (match_players 테이블)
+type matchPlayerRecord struct {
+	seat        int
+	accountID   string // 로그인 계정 (봇이나 로그인하지 않은 플레이어는 빈 문자열)
+	name        string
+	isBot       bool
+	finalCards  int
+	rank        int
+	bellHits    int
+	bellMisses  int
+	fastestRing int // 가장 빠른 올바른 벨 반응 시간 (밀리초, 0이면 기록 없음)
+}
+
+// 게임 종료 결과로 게임 기록 생성
+func newMatchRecord(room *Room, result *game.GameResult, endedAt time.Time) *matchRecord {
+	record := &matchRecord{
+		id:        endedAt.Format("20060102150405") + "-" + generateRandomCode(6),
+		roomID:    room.id,
+		startedAt: result.StartedAt,
+		endedAt:   endedAt,
+		config:    room.gameConfig,
+		players:   make([]matchPlayerRecord, len(result.PlayerIDs)),
+	}
+
+	for seat, playerID := range result.PlayerIDs {
+		record.players[seat] = matchPlayerRecord{
+			seat:        seat,
+			accountID:   room.seatAccount(playerID),
+			name:        result.PlayerNames[seat],
+			isBot:       result.IsBot[seat],
+			finalCards:  result.PlayerCards[seat],
+			rank:        result.PlayerRanks[seat],
+			bellHits:    result.BellHits[seat],
+			bellMisses:  result.BellMisses[seat],
+			fastestRing: result.FastestRing[seat],
+		}
+	}
+	return record
+}
+
+// 게임 기록 저장 (실패해도 게임 진행에는 영향이 없으므로 로그만 남김)
+func saveMatch(record *matchRecord) {
+	if err := insertMatch(record); err != nil {
+		log.Printf("게임 기록 저장 오류: 게임=%s, 방=%s, 오류=%v", record.id, record.roomID, err)
+		return
+	}
+	log.Printf("게임 기록 저장 완료: 게임=%s, 방=%s, 플레이어 %d명", record.id, record.roomID, len(record.players))
+}
+
+// 게임 기록과 플레이어별 기록을 한 트랜잭션으로 저장
+func insertMatch(record *matchRecord) error {
+	configJSON, err := json.Marshal(record.config)
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(
+		"INSERT INTO matches (id, room_id, started_at, ended_at, config) VALUES ($1, $2, $3, $4, $5)",
+		record.id, record.roomID, record.startedAt, record.endedAt, configJSON,
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, player := range record.players {
+		accountID := sql.NullString{String: player.accountID, Valid: player.accountID != ""}
+		fastestRing := sql.NullInt64{Int64: int64(player.fastestRing), Valid: player.fastestRing > 0}
+		_, err = tx.Exec(`
+			INSERT INTO match_players (match_id, seat, account_id, player_name, is_bot, final_cards, rank, bell_hits, bell_misses, fastest_ring_ms)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		`, record.id, player.seat, accountID, player.name, player.isBot, player.finalCards, player.rank, player.bellHits, player.bellMisses, fastestRing)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := updateRatings(tx, record); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// 로그인한 플레이어들의 레이팅을 이번 게임 순위로 갱신 (봇과 로그인하지 않은 플레이어는 제외)
+// 같은 계정이 여러 좌석에 앉았으면 첫 좌석만 반영하며, 반영할 플레이어가 두 명 미만이면 갱신하지 않음
+func updateRatings(tx *sql.Tx, record *matchRecord) error {
+	var rated []matchPlayerRecord
+	seen := make(map[string]bool)
+	for _, player := range record.players {
+		if player.accountID == "" || player.isBot || seen[player.accountID] {
+			continue
+		}
+		seen[player.accountID] = true
+		rated = append(rated, player)
+	}
+	if len(rated) < 2 {
+		return nil
+	}
+
+	// 동시에 끝난 게임끼리 교착되지 않도록 계정 아이디 순서로 잠금
+	sort.Slice(rated, func(i, j int) bool {
+		return rated[i].accountID < rated[j].accountID
+	})
+
+	ratings := make([]float64, len(rated))
+	ranks := make([]int, len(rated))
+	for i, player := range rated {
+		if err := tx.QueryRow("SELECT rating FROM Users WHERE id = $1 FOR UPDATE", player.accountID).Scan(&ratings[i]); err != nil {
+			return err
+		}
+		ranks[i] = player.rank
+	}
+
+	updated := game.UpdateRatings(ratings, ranks, config.RatingK)
+	for i, player := range rated {
+		if _, err := tx.Exec("UPDATE Users SET rating = $1, rated_games = rated_games + 1 WHERE id = $2", updated[i], player.accountID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec("UPDATE match_players SET rating_change = $1 WHERE match_id = $2 AND seat = $3", updated[i]-ratings[i], record.id, player.seat); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 전적 조회 처리 (accountId가 비어있으면 내 전적)
+func (h *Handler) handlePlayerStats(client *Client, statsData *RequestPlayerStatsData) {
+	accountID := statsData.AccountID
+	if accountID == "" {
+		accountID = client.AccountID
+	}
+
+	stats, err := loadPlayerStats(accountID)
+	if err != nil {
+		if !errors.Is(err, ErrAccountNotFound) {
+			log.Printf("전적 조회 오류: ID=%s, 오류=%v", accountID, err)
+			err = errors.New("서버 오류로 전적을 조회하지 못했습니다")
+		}
+		h.sendErrorFor(client, RequestPlayerStats, err)
+		return
+	}
+
+	response := NewSuccessResponse(ResponsePlayerStats, stats)
+	h.sendToClient(client, response)
+}
+
+// 계정의 승률, 평균 순위, 벨 정확도 집계
+func loadPlayerStats(accountID string) (*PlayerStatsData, error) {
+	stats := &PlayerStatsData{AccountID: accountID}
+
+	var rating float64
+	err := db.DB.QueryRow("SELECT nickname, rating, rated_games FROM Users WHERE id = $1", accountID).Scan(&stats.Nickname, &rating, &stats.RatedGames)
+	if err == sql.ErrNoRows {
+		return nil, ErrAccountNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.DB.QueryRow(`
+		SELECT COUNT(*),
+			COUNT(*) FILTER (WHERE rank = 1),
+			COALESCE(AVG(rank), 0),
+			COALESCE(SUM(bell_hits), 0),
+			COALESCE(SUM(bell_misses), 0)
+		FROM match_players
+		WHERE account_id = $1
+	`, accountID).Scan(&stats.Matches, &stats.Wins, &stats.AverageRank, &stats.BellHits, &stats.BellMisses)
+	if err != nil {
+		return nil, err
+	}
+
+	stats.Rating = int(math.Round(rating))
+	if stats.Matches > 0 {
+		stats.WinRate = float64(stats.Wins) / float64(stats.Matches)
+	}
+	if bellPresses := stats.BellHits + stats.BellMisses; bellPresses > 0 {
+		stats.BellAccuracy = float64(stats.BellHits) / float64(bellPresses)
+	}
+	return stats, nil
+}
+
+// 순위표 조회 처리 (주기적으로 집계해 둔 순위표를 그대로 응답)
+func (h *Handler) handleLeaderboard(client *Client, boardData *RequestLeaderboardData) {
+	if h.leaderboard == nil {
+		h.sendErrorFor(client, RequestLeaderboard, leaderboard.ErrNotReady)
+		return
+	}
+
+	board, err := h.leaderboard.Board(leaderboard.Category(boardData.Category), leaderboard.Period(boardData.Period))
+	if err != nil {
+		h.sendErrorFor(client, RequestLeaderboard, err)
+		return
+	}
+
+	response := NewSuccessResponse(ResponseLeaderboard, board)
+	h.sendToClient(client, response)
+}
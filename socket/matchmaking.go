@@ -0,0 +1,270 @@
+package socket
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"main/config"
+	"main/db"
+)
+
+// 매칭 대기열 에러
+var (
+	ErrAlreadyInQueue = errors.New("이미 매칭을 기다리는 중입니다")
+	ErrNotInQueue     = errors.New("매칭을 기다리는 중이 아닙니다")
+)
+
+// 매칭을 기다리는 클라이언트
+type queueEntry struct {
+	client   *Client
+	rating   float64
+	joinedAt time.Time
+}
+
+// 레이팅 기반 매칭 대기열 (들어온 순서대로 보관하고 오래 기다린 클라이언트부터 매칭)
+type matchQueue struct {
+	mu      sync.Mutex
+	entries []*queueEntry
+}
+
+func newMatchQueue() *matchQueue {
+	return &matchQueue{}
+}
+
+// 대기열에 추가 (이미 기다리는 중이면 false)
+func (q *matchQueue) add(entry *queueEntry) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for _, existing := range q.entries {
+		if existing.client == entry.client {
+			return false
+		}
+	}
+	q.entries = append(q.entries, entry)
+	return true
+}
+
+// 대기열에서 제거 (기다리는 중이 아니었으면 false)
+func (q *matchQueue) remove(client *Client) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for i, entry := range q.entries {
+		if entry.client == client {
+			q.entries = append(q.entries[:i], q.entries[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// 대기 중인 클라이언트 수
+func (q *matchQueue) size() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.entries)
+}
+
+// 대기 시간에 따른 허용 레이팅 차이 (오래 기다릴수록 넓어짐)
+func matchTolerance(wait time.Duration) float64 {
+	tolerance := config.MatchmakingBaseTolerance + config.MatchmakingTolerancePerSecond*wait.Seconds()
+	return math.Min(tolerance, config.MatchmakingMaxTolerance)
+}
+
+// 레이팅이 비슷한 클라이언트끼리 묶어 대기열에서 꺼냄 (남은 대기열도 함께 반환)
+// 서로의 허용 레이팅 차이 안에 드는 클라이언트를 groupSize명까지 묶고,
+// MatchmakingPartialAfter 이상 기다린 클라이언트는 minSize명만 모여도 묶음
+// 다른 방에 들어갔거나 로그아웃한 클라이언트는 대기열에서 빠짐
+func (q *matchQueue) takeGroups(now time.Time, groupSize, minSize int) ([][]*queueEntry, []*queueEntry) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	waiting := q.entries[:0]
+	for _, entry := range q.entries {
+		if !matchable(entry.client) {
+			log.Printf("매칭 대기열에서 제외: %s (방 참여 또는 로그아웃)", entry.client.ID)
+			continue
+		}
+		waiting = append(waiting, entry)
+	}
+	q.entries = waiting
+
+	var groups [][]*queueEntry
+	matched := make(map[*queueEntry]bool)
+	for _, anchor := range q.entries {
+		if matched[anchor] {
+			continue
+		}
+
+		anchorTolerance := matchTolerance(now.Sub(anchor.joinedAt))
+		var candidates []*queueEntry
+		for _, entry := range q.entries {
+			if entry == anchor || matched[entry] {
+				continue
+			}
+			diff := math.Abs(entry.rating - anchor.rating)
+			if diff <= anchorTolerance && diff <= matchTolerance(now.Sub(entry.joinedAt)) {
+				candidates = append(candidates, entry)
+			}
+		}
+
+		// 레이팅이 가까운 순서로 채움
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return math.Abs(candidates[i].rating-anchor.rating) < math.Abs(candidates[j].rating-anchor.rating)
+		})
+		if len(candidates) > groupSize-1 {
+			candidates = candidates[:groupSize-1]
+		}
+
+		waitedLong := now.Sub(anchor.joinedAt) >= config.MatchmakingPartialAfter*time.Second
+		if len(candidates)+1 < groupSize && !(waitedLong && len(candidates)+1 >= minSize) {
+			continue
+		}
+
+		group := append([]*queueEntry{anchor}, candidates...)
+		for _, entry := range group {
+			matched[entry] = true
+		}
+		groups = append(groups, group)
+	}
+
+	remaining := make([]*queueEntry, 0, len(q.entries))
+	for _, entry := range q.entries {
+		if !matched[entry] {
+			remaining = append(remaining, entry)
+		}
+	}
+	q.entries = remaining
+
+	left := make([]*queueEntry, len(remaining))
+	copy(left, remaining)
+	return groups, left
+}
+
+// 매칭 대기 처리 (레이팅을 불러와 대기열에 추가)
+func (h *Handler) handleJoinQueue(client *Client) {
+	if client.IsInRoom || client.IsSpectating {
+		h.sendErrorWithSignal(client, RequestJoinQueue, ErrCodeAlreadyInRoom, "이미 방에 참여한 상태입니다")
+		return
+	}
+
+	rating, err := loadRating(client.AccountID)
+	if err != nil {
+		log.Printf("레이팅 조회 오류: ID=%s, 오류=%v", client.AccountID, err)
+		h.sendErrorWithSignal(client, RequestJoinQueue, ErrCodeServerError, "서버 오류로 매칭을 시작하지 못했습니다")
+		return
+	}
+
+	entry := &queueEntry{client: client, rating: rating, joinedAt: time.Now()}
+	if !h.queue.add(entry) {
+		h.sendErrorFor(client, RequestJoinQueue, ErrAlreadyInQueue)
+		return
+	}
+
+	log.Printf("매칭 대기 시작: %s (%s) - 레이팅: %.0f", client.ID, client.Nickname, rating)
+
+	response := NewSuccessResponse(ResponseJoinQueue, h.queueStatus(entry, time.Now()))
+	h.sendToClient(client, response)
+}
+
+// 매칭 대기 취소 처리
+func (h *Handler) handleLeaveQueue(client *Client) {
+	if !h.queue.remove(client) {
+		h.sendErrorFor(client, RequestLeaveQueue, ErrNotInQueue)
+		return
+	}
+
+	log.Printf("매칭 대기 취소: %s", client.ID)
+
+	response := NewSuccessResponse(ResponseLeaveQueue, map[string]interface{}{})
+	h.sendToClient(client, response)
+}
+
+// 매칭 루프 (설정된 간격마다 매칭을 시도하고 대기 중인 클라이언트에게 대기 상태 전송)
+func (h *Handler) runMatchmaking() {
+	ticker := time.NewTicker(config.MatchmakingInterval * time.Second)
+	defer ticker.Stop()
+
+	statusEvery := config.MatchmakingStatusInterval / config.MatchmakingInterval
+	for tick := 1; ; tick++ {
+		now := <-ticker.C
+
+		gameConfig := config.GetDefaultConfig()
+		groups, waiting := h.queue.takeGroups(now, gameConfig.MaxPlayers, gameConfig.MinPlayers)
+		for _, group := range groups {
+			h.startMatchedRoom(group, gameConfig)
+		}
+
+		if tick%statusEvery == 0 {
+			for _, entry := range waiting {
+				response := NewSuccessResponse(ResponseQueueStatus, h.queueStatus(entry, now))
+				h.sendToClient(entry.client, response)
+			}
+		}
+	}
+}
+
+// 매칭된 클라이언트들로 비공개 방을 만들고 입장시킴 (카운트다운 방식이라 빠진 클라이언트가 있어도 최소 인원이면 시작)
+func (h *Handler) startMatchedRoom(group []*queueEntry, gameConfig *config.GameConfig) {
+	room := h.rooms.CreateRoom("", true, config.StartPolicyCountdown, gameConfig)
+
+	joined := 0
+	for _, entry := range group {
+		// 매칭된 뒤 직접 방에 들어갔거나 관전/로그아웃/연결 해제한 클라이언트는 건너뜀 (이미 대기열에서 빠진 상태)
+		if !matchable(entry.client) {
+			log.Printf("매칭 입장 건너뜀: %s (방 참여, 로그아웃 또는 연결 해제)", entry.client.ID)
+			continue
+		}
+		if !h.joinRoom(entry.client, room, RequestJoinQueue) {
+			continue
+		}
+		joined++
+
+		response := NewSuccessResponse(ResponseMatchFound, room.Info())
+		h.sendToClient(entry.client, response)
+	}
+
+	if joined == 0 {
+		h.rooms.RemoveRoom(room.id)
+		return
+	}
+
+	log.Printf("매칭 완료: 방 %s - %d명", room.id, joined)
+
+	// 게임 시작 조건 확인
+	h.checkAndStartGame(room)
+}
+
+// 매칭된 방에 넣을 수 있는 클라이언트인지 확인 (연결 중이고 방에 참여/관전 중이 아니며 로그인한 상태)
+func matchable(client *Client) bool {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	return !client.isClosed() && !client.IsInRoom && !client.IsSpectating && client.AccountID != ""
+}
+
+// 대기 상태 데이터
+func (h *Handler) queueStatus(entry *queueEntry, now time.Time) *QueueStatusData {
+	wait := now.Sub(entry.joinedAt)
+	return &QueueStatusData{
+		Rating:      int(math.Round(entry.rating)),
+		QueueSize:   h.queue.size(),
+		WaitSeconds: int(wait.Seconds()),
+		Tolerance:   int(matchTolerance(wait)),
+	}
+}
+
+// 계정의 현재 레이팅
+func loadRating(accountID string) (float64, error) {
+	var rating float64
+	err := db.DB.QueryRow("SELECT rating FROM Users WHERE id = $1", accountID).Scan(&rating)
+	if err == sql.ErrNoRows {
+		return config.DefaultRating, nil
+	}
+	return rating, err
+}
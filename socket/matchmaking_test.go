@@ -0,0 +1,152 @@
+package socket
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestMatchTolerance(t *testing.T) {
+	tests := []struct {
+		wait time.Duration
+		want float64
+	}{
+		{0, 100},
+		{15 * time.Second, 250},
+		{60 * time.Second, 700},
+		{10 * time.Minute, 1000}, // 최대값에서 멈춤
+	}
+
+	for _, tt := range tests {
+		if got := matchTolerance(tt.wait); got != tt.want {
+			t.Errorf("matchTolerance(%v) = %v, want %v", tt.wait, got, tt.want)
+		}
+	}
+}
+
+func TestTakeGroups(t *testing.T) {
+	// 대기열 항목 (waitSeconds만큼 기다린 상태, unmatchable이면 이미 방에 들어간 클라이언트)
+	type entrySpec struct {
+		rating      float64
+		waitSeconds int
+		unmatchable bool
+		loggedOut   bool
+	}
+
+	tests := []struct {
+		name          string
+		entries       []entrySpec
+		groupSize     int
+		minSize       int
+		wantGroups    [][]int // 묶인 항목 번호 (묶음 안에서는 오름차순)
+		wantRemaining []int
+	}{
+		{
+			name:          "허용 차이 밖이면 묶지 않음",
+			entries:       []entrySpec{{1500, 0, false, false}, {1700, 0, false, false}},
+			groupSize:     2,
+			minSize:       2,
+			wantRemaining: []int{0, 1},
+		},
+		{
+			name:       "기다리면 허용 차이가 넓어져 묶음",
+			entries:    []entrySpec{{1500, 15, false, false}, {1700, 15, false, false}},
+			groupSize:  2,
+			minSize:    2,
+			wantGroups: [][]int{{0, 1}},
+		},
+		{
+			name:          "한쪽만 오래 기다리면 묶지 않음",
+			entries:       []entrySpec{{1500, 60, false, false}, {1700, 0, false, false}},
+			groupSize:     2,
+			minSize:       2,
+			wantRemaining: []int{0, 1},
+		},
+		{
+			name:          "레이팅이 가까운 순서로 채움",
+			entries:       []entrySpec{{1500, 0, false, false}, {1590, 0, false, false}, {1510, 0, false, false}},
+			groupSize:     2,
+			minSize:       2,
+			wantGroups:    [][]int{{0, 2}},
+			wantRemaining: []int{1},
+		},
+		{
+			name:          "최대 인원이 모이지 않으면 기다림",
+			entries:       []entrySpec{{1500, 30, false, false}, {1500, 30, false, false}, {1500, 30, false, false}},
+			groupSize:     4,
+			minSize:       2,
+			wantRemaining: []int{0, 1, 2},
+		},
+		{
+			name:       "오래 기다리면 최소 인원으로 묶음",
+			entries:    []entrySpec{{1500, 60, false, false}, {1500, 5, false, false}, {1500, 5, false, false}},
+			groupSize:  4,
+			minSize:    2,
+			wantGroups: [][]int{{0, 1, 2}},
+		},
+		{
+			name:          "오래 기다려도 최소 인원보다 적으면 묶지 않음",
+			entries:       []entrySpec{{1500, 120, false, false}, {1500, 120, false, false}},
+			groupSize:     4,
+			minSize:       3,
+			wantRemaining: []int{0, 1},
+		},
+		{
+			name: "방에 들어갔거나 로그아웃한 클라이언트는 빠짐",
+			entries: []entrySpec{
+				{1500, 0, false, false},
+				{1500, 0, true, false},
+				{1500, 0, false, true},
+				{1500, 0, false, false},
+			},
+			groupSize:  2,
+			minSize:    2,
+			wantGroups: [][]int{{0, 3}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Now()
+			q := newMatchQueue()
+			index := make(map[*queueEntry]int)
+			for i, spec := range tt.entries {
+				client := &Client{ID: fmt.Sprintf("c%d", i), AccountID: fmt.Sprintf("account%d", i), IsInRoom: spec.unmatchable}
+				if spec.loggedOut {
+					client.AccountID = ""
+				}
+				entry := &queueEntry{client: client, rating: spec.rating, joinedAt: now.Add(-time.Duration(spec.waitSeconds) * time.Second)}
+				index[entry] = i
+				q.add(entry)
+			}
+
+			groups, remaining := q.takeGroups(now, tt.groupSize, tt.minSize)
+
+			var gotGroups [][]int
+			for _, group := range groups {
+				var ids []int
+				for _, entry := range group {
+					ids = append(ids, index[entry])
+				}
+				sort.Ints(ids)
+				gotGroups = append(gotGroups, ids)
+			}
+			var gotRemaining []int
+			for _, entry := range remaining {
+				gotRemaining = append(gotRemaining, index[entry])
+			}
+
+			if !reflect.DeepEqual(gotGroups, tt.wantGroups) {
+				t.Errorf("groups = %v, want %v", gotGroups, tt.wantGroups)
+			}
+			if !reflect.DeepEqual(gotRemaining, tt.wantRemaining) {
+				t.Errorf("remaining = %v, want %v", gotRemaining, tt.wantRemaining)
+			}
+			if q.size() != len(tt.wantRemaining) {
+				t.Errorf("대기열 크기 = %d, want %d", q.size(), len(tt.wantRemaining))
+			}
+		})
+	}
+}
@@ -3,40 +3,81 @@ package socket
 import (
 	"encoding/json"
 	"log"
+	"regexp"
+
+	"main/config"
 )
 
 // 패킷 시그널 상수 (서버 -> 클라이언트)
 const (
-	ResponsePong      = 1
-	ResponseEnterRoom = 1001
-	ResponseLeaveRoom = 1002
-	ResponseStartGame = 1010
-	ResponseReadyGame = 1011
+	ResponsePong           = 1
+	ResponseHello          = 2
+	ResponseLatencyWarning = 3
+	ResponseEnterRoom      = 1001
+	ResponseLeaveRoom      = 1002
+	ResponseCreateRoom     = 1003
+	ResponseRoomList       = 1004
+	ResponseJoinRoom       = 1005
+	ResponseReconnect      = 1006
+	ResponseSnapshot       = 1007
+	ResponseSpectateRoom   = 1008
+	ResponseStartGame      = 1010
+	ResponseReadyGame      = 1011
+	ResponseAddBot         = 1012
+	ResponseRemoveBot      = 1013
+	ResponseToggleReady    = 1014
+	ResponseStartCountdown = 1015
+	ResponseJoinQueue      = 1016
+	ResponseLeaveQueue     = 1017
+	ResponseQueueStatus    = 1018
+	ResponseMatchFound     = 1019
 
 	ResponseOpenCard        = 2000
 	ResponseRingBellCorrect = 2002
 	ResponseRingBellWrong   = 2003
 	ResponseEmotion         = 2004
+	ResponseRoomLatency     = 2005
 
 	ResponseEndGame = 3000
 
 	ResponseCreateAccount  = 4000
 	ResponseLogin          = 4001
 	ResponseChangeNickName = 4002
+	ResponseSessionLogin   = 4003
+	ResponseLogout         = 4004
+	ResponsePlayerStats    = 4005
+	ResponseLeaderboard    = 4006
 )
 
 // 클라이언트 요청 시그널 상수 (클라이언트 -> 서버)
 const (
-	RequestPing      = 1
-	RequestEnterRoom = 1001
-	RequestLeaveRoom = 1002
-	RequestReadyGame = 1011
-	RequestRingBell  = 2001
-	RequestEmotion   = 2004
+	RequestPing         = 1
+	RequestHello        = 2
+	RequestEnterRoom    = 1001
+	RequestLeaveRoom    = 1002
+	RequestCreateRoom   = 1003
+	RequestRoomList     = 1004
+	RequestJoinRoom     = 1005
+	RequestReconnect    = 1006
+	RequestSnapshot     = 1007
+	RequestSpectateRoom = 1008
+	RequestStartGame    = 1010
+	RequestReadyGame    = 1011
+	RequestAddBot       = 1012
+	RequestRemoveBot    = 1013
+	RequestToggleReady  = 1014
+	RequestJoinQueue    = 1016
+	RequestLeaveQueue   = 1017
+	RequestRingBell     = 2001
+	RequestEmotion      = 2004
 
 	RequestCreateAccount  = 4000
 	RequestLogin          = 4001
-	requestChangeNickName = 4002
+	RequestChangeNickName = 4002
+	RequestSessionLogin   = 4003
+	RequestLogout         = 4004
+	RequestPlayerStats    = 4005
+	RequestLeaderboard    = 4006
 )
 
 // 패킷 구조체 - 모든 클라이언트 응답에 사용
@@ -46,10 +87,10 @@ type ResponsePacket struct {
 	Code   int         `json:"code"`
 }
 
-// 클라이언트 요청 패킷 구조체
+// 클라이언트 요청 패킷 구조체 (data는 signal별 구조체로 디코딩하기 전까지 원본 JSON으로 보관)
 type RequestPacket struct {
-	Signal int         `json:"signal"`
-	Data   interface{} `json:"data"`
+	Signal int             `json:"signal"`
+	Data   json.RawMessage `json:"data"`
 }
 
 // 성공 코드
@@ -71,8 +112,18 @@ func NewSuccessResponse(signal int, data interface{}) *ResponsePacket {
 	return NewResponse(signal, data, CodeSuccess)
 }
 
-func NewErrorResponse(requestSignal int, message string) *ResponsePacket {
-	return NewResponse(requestSignal, map[string]interface{}{}, CodeError)
+func NewErrorResponse(requestSignal int, errorCode ErrorCode, message string) *ResponsePacket {
+	return NewResponse(requestSignal, &ErrorData{ErrorCode: errorCode, Message: message}, CodeError)
+}
+
+// 요청 데이터 검증 실패 응답 (어떤 필드가 왜 잘못되었는지 포함)
+func NewPayloadErrorResponse(requestSignal int, err *PayloadError) *ResponsePacket {
+	return NewResponse(requestSignal, &ErrorData{
+		ErrorCode: ErrCodeInvalidPayload,
+		Message:   err.Message,
+		Field:     err.Field,
+		Reason:    err.Reason,
+	}, CodeError)
 }
 
 // 패킷을 JSON으로 마샬링
@@ -80,41 +131,34 @@ func (p *ResponsePacket) ToJSON() ([]byte, error) {
 	return json.Marshal(p)
 }
 
-// 패킷을 JSON으로 마샬링하고 로그 출력
+// 로그에 남기지 않을 인증 정보 필드 (세션 토큰, 재접속 토큰)
+var sensitiveFieldPattern = regexp.MustCompile(`"(sessionToken|resumeToken)":"[^"]*"`)
+
+// 패킷을 JSON으로 마샬링하고 로그 출력 (인증 정보는 가려서 출력)
 func (p *ResponsePacket) ToJSONWithLog() ([]byte, error) {
 	data, err := json.Marshal(p)
 	if err != nil {
 		log.Printf("패킷 마샬링 오류: %v", err)
 		return nil, err
 	}
-	log.Printf("전송 패킷: %s", string(data))
+	log.Printf("전송 패킷: %s", redactSensitiveFields(data))
 	return data, nil
 }
 
-// 클라이언트 요청 패킷 검증
+// 로그 출력용으로 인증 정보 필드의 값을 가림
+func redactSensitiveFields(data []byte) string {
+	return sensitiveFieldPattern.ReplaceAllString(string(data), `"$1":"[REDACTED]"`)
+}
+
+// 클라이언트 요청 패킷 검증 (signal 확인은 등록된 요청 처리기에서)
 func ValidateRequestPacket(data []byte) (*RequestPacket, error) {
 	var request RequestPacket
 	if err := json.Unmarshal(data, &request); err != nil {
 		return nil, err
 	}
 
-	// signal이 유효한지 확인
-	validSignals := map[int]bool{
-		RequestPing:          true,
-		RequestEnterRoom:     true,
-		RequestLeaveRoom:     true,
-		RequestReadyGame:     true,
-		RequestRingBell:      true,
-		RequestEmotion:       true,
-		RequestCreateAccount: true,
-	}
-
-	if !validSignals[request.Signal] {
-		return nil, &InvalidPacketError{Message: "유효하지 않은 signal"}
-	}
-
 	// data가 nil이 아닌지 확인
-	if request.Data == nil {
+	if len(request.Data) == 0 || string(request.Data) == "null" {
 		return nil, &InvalidPacketError{Message: "data가 nil입니다"}
 	}
 
@@ -130,18 +174,147 @@ func (e *InvalidPacketError) Error() string {
 	return e.Message
 }
 
+// 에러 응답 데이터 구조체
+type ErrorData struct {
+	ErrorCode ErrorCode `json:"errorCode"`        // 에러 종류를 구분하는 코드 (errors.go 참고)
+	Message   string    `json:"message"`          // 안내 메시지 (한국어 기본 문구)
+	Field     string    `json:"field,omitempty"`  // 요청 데이터 검증 실패 시 문제가 된 필드
+	Reason    string    `json:"reason,omitempty"` // 요청 데이터 검증 실패 사유 코드 (invalidFormat, invalidType, required, empty, tooLong, outOfRange)
+}
+
+// 핸드셰이크 요청 데이터 구조체
+type RequestHelloData struct {
+	ProtocolVersion int      `json:"protocolVersion" label:"프로토콜 버전" validate:"required"` // 클라이언트 프로토콜 버전
+	Capabilities    []string `json:"capabilities"`                                        // 클라이언트가 지원하는 부가 기능
+}
+
+// 핸드셰이크 응답 데이터 구조체
+type ResponseHelloData struct {
+	ProtocolVersion    int               `json:"protocolVersion"`    // 이 연결에서 사용할 프로토콜 버전
+	ServerVersion      int               `json:"serverVersion"`      // 서버 프로토콜 버전
+	MinProtocolVersion int               `json:"minProtocolVersion"` // 서버가 받아주는 가장 낮은 클라이언트 버전
+	Signals            []int             `json:"signals"`            // 서버가 처리하는 요청 signal 목록
+	Capabilities       []string          `json:"capabilities"`       // 이 연결에서 사용할 부가 기능 (양쪽이 모두 지원하는 것)
+	ServerCapabilities []string          `json:"serverCapabilities"` // 서버가 지원하는 부가 기능
+	Encoding           string            `json:"encoding"`           // 이 연결의 패킷 인코딩 방식 (json, msgpack)
+	Config             config.GameConfig `json:"config"`             // 새로 만드는 방의 기본 게임 설정
+}
+
+// 방 정보 데이터 구조체 (방 목록, 방 생성/참여 응답에 사용)
+type RoomInfoData struct {
+	RoomID        string `json:"roomId"`        // 방 ID
+	Name          string `json:"name"`          // 방 이름
+	PlayerCount   int    `json:"playerCount"`   // 현재 인원
+	MinPlayers    int    `json:"minPlayers"`    // 게임 시작에 필요한 최소 인원
+	MaxPlayers    int    `json:"maxPlayers"`    // 최대 인원
+	IsGameStarted bool   `json:"isGameStarted"` // 게임 진행 여부
+	IsPrivate     bool   `json:"isPrivate"`     // 비공개 방 여부
+	StartPolicy   string `json:"startPolicy"`   // 게임 시작 방식
+}
+
+// 방 생성 요청 데이터 구조체
+type RequestCreateRoomData struct {
+	Name        string          `json:"name" label:"방 이름" validate:"max=20"` // 방 이름
+	IsPrivate   bool            `json:"isPrivate"`                           // 비공개 방 여부
+	StartPolicy string          `json:"startPolicy"`                         // 게임 시작 방식 (생략 시 fullRoom)
+	Config      json.RawMessage `json:"config"`                              // 게임 설정 (생략한 값은 기본값 사용, 기본값 위에 덮어써서 검증)
+}
+
+// 방 생성 응답 데이터 구조체
+type ResponseCreateRoomData struct {
+	RoomInfoData
+	Code string `json:"code,omitempty"` // 비공개 방 참여 코드
+}
+
+// 방 목록 응답 데이터 구조체
+type ResponseRoomListData struct {
+	Rooms []RoomInfoData `json:"rooms"` // 공개 방 목록
+}
+
+// 방 참여/관전 요청 데이터 구조체 (roomId 또는 code 중 하나 필요)
+type RequestJoinRoomData struct {
+	RoomID string `json:"roomId"` // 참여할 방 ID
+	Code   string `json:"code"`   // 비공개 방 참여 코드
+}
+
+// 봇 추가 요청 데이터 구조체
+type RequestAddBotData struct {
+	Difficulty string `json:"difficulty"` // 봇 난이도 (easy, normal, hard, 생략 시 normal)
+}
+
+// 봇 제거 요청 데이터 구조체
+type RequestRemoveBotData struct {
+	BotID string `json:"botId"` // 제거할 봇 ID (생략 시 아무 봇이나 제거)
+}
+
+// 봇 추가/제거 응답 데이터 구조체
+type ResponseBotData struct {
+	RoomInfoData
+	BotID      string `json:"botId"`      // 추가/제거된 봇 ID
+	Username   string `json:"username"`   // 봇 이름
+	Difficulty string `json:"difficulty"` // 봇 난이도
+}
+
+// 대기실 준비 상태 변경 데이터 구조체 (방의 모든 플레이어에게 전송)
+type ToggleReadyData struct {
+	Username    string `json:"username"`    // 준비 상태를 바꾼 플레이어 이름
+	IsReady     bool   `json:"isReady"`     // 변경된 준비 상태
+	ReadyCount  int    `json:"readyCount"`  // 준비를 마친 플레이어 수
+	PlayerCount int    `json:"playerCount"` // 현재 인원
+}
+
+// 게임 시작 카운트다운 데이터 구조체
+type StartCountdownData struct {
+	Seconds     int  `json:"seconds"`     // 게임 시작까지 남은 시간 (초)
+	IsCancelled bool `json:"isCancelled"` // 인원이 줄어 카운트다운이 취소되었는지
+}
+
+// 매칭 대기 상태 데이터 구조체 (매칭 대기 응답과 주기적인 대기 상태 알림에 사용)
+type QueueStatusData struct {
+	Rating      int `json:"rating"`      // 내 레이팅 (반올림)
+	QueueSize   int `json:"queueSize"`   // 매칭을 기다리는 인원
+	WaitSeconds int `json:"waitSeconds"` // 기다린 시간 (초)
+	Tolerance   int `json:"tolerance"`   // 현재 허용하는 레이팅 차이 (기다릴수록 넓어짐)
+}
+
+// 재접속 요청 데이터 구조체
+type RequestReconnectData struct {
+	ResumeToken string `json:"resumeToken" label:"재접속 토큰" validate:"required,nonempty"` // 연결 시 Pong으로 받은 재접속 토큰
+}
+
+// 방 상태 스냅샷 데이터 구조체 (재접속 및 상태 재동기화 시 전체 상태 복원용)
+type RoomSnapshotData struct {
+	RoomID             string            `json:"roomId"`             // 방 ID
+	IsGameStarted      bool              `json:"isGameStarted"`      // 게임 시작 여부
+	IsCardGameStarted  bool              `json:"isCardGameStarted"`  // 카드 공개 시작 여부
+	PlayerCount        int               `json:"playerCount"`        // 총 플레이어 수
+	PlayerNames        []string          `json:"playerNames"`        // 좌석 순서대로의 플레이어 이름
+	PlayerConnected    []bool            `json:"playerConnected"`    // 각 플레이어의 연결 여부
+	MyIndex            int               `json:"myIndex"`            // 받는 클라이언트의 플레이어 인덱스 (-1: 좌석 없음)
+	PlayerCards        []int             `json:"playerCards"`        // 각 플레이어별 손패 카드 개수
+	OpenCards          []int             `json:"openCards"`          // 각 플레이어가 공개한 카드 개수
+	PublicFruitIndexes []int             `json:"publicFruitIndexes"` // 각 플레이어의 맨 위 공개 카드 과일 인덱스 (-1: 없음)
+	PublicFruitCounts  []int             `json:"publicFruitCounts"`  // 각 플레이어의 맨 위 공개 카드 과일 개수 (-1: 없음)
+	CurrentPlayerIndex int               `json:"currentPlayerIndex"` // 다음에 카드를 낼 플레이어 인덱스
+	BellRung           bool              `json:"bellRung"`           // 현재 공개된 카드에 대해 벨이 눌렸는지
+	IsTimeExpired      bool              `json:"isTimeExpired"`      // 시간제한이 끝났는지
+	RemainingTimeMs    int64             `json:"remainingTimeMs"`    // 남은 게임 제한시간 (밀리초)
+	Config             config.GameConfig `json:"config"`             // 방의 게임 설정
+}
+
 // 게임 시작 데이터 구조체
 type GameStartData struct {
-	PlayerCount   int      `json:"playerCount"`
-	PlayerNames   []string `json:"playerNames"`
-	MyIndex       int      `json:"myIndex"`
-	StartingCards int      `json:"startingCards"`
-	GameTimeLimit int      `json:"gameTimeLimit"` // 게임 제한시간 (초)
+	PlayerCount   int               `json:"playerCount"`
+	PlayerNames   []string          `json:"playerNames"`
+	MyIndex       int               `json:"myIndex"`
+	StartingCards int               `json:"startingCards"`
+	GameTimeLimit int               `json:"gameTimeLimit"` // 게임 제한시간 (초)
+	Config        config.GameConfig `json:"config"`        // 방의 게임 설정
 }
 
 // 카드 공개 데이터 구조체
 type OpenCardData struct {
-	FruitIndex  int `json:"fruitIndex"`  // 0-2 (과일 종류)
+	FruitIndex  int `json:"fruitIndex"`  // 0-3 (과일 종류)
 	FruitCount  int `json:"fruitCount"`  // 1-5 (과일 개수)
 	PlayerIndex int `json:"playerIndex"` // 카드를 낸 플레이어 인덱스
 }
@@ -150,6 +323,7 @@ type OpenCardData struct {
 type RingBellCorrectData struct {
 	PlayerIndex int   `json:"playerIndex"` // 벨을 누른 플레이어 인덱스
 	PlayerCards []int `json:"playerCards"` // 각 플레이어별 덱의 카드 개수 배열
+	ReactionMs  int   `json:"reactionMs"`  // 카드 공개부터 서버가 벨 누름을 받기까지 걸린 시간 (밀리초, 지연 보정 없음)
 }
 
 // 벨 누르기 실패 데이터 구조체
@@ -159,6 +333,26 @@ type RingBellWrongData struct {
 	PlayerCards []int  `json:"playerCards"` // 각 플레이어별 덱의 카드 개수 배열
 }
 
+// 방 지연 시간 데이터 구조체 (핑 간격마다 방의 플레이어와 관전자에게 전송)
+type RoomLatencyData struct {
+	Players []PlayerLatencyData `json:"players"` // 연결된 플레이어들의 지연 시간 (좌석 순서, 봇과 연결이 끊긴 플레이어 제외)
+}
+
+// 플레이어 지연 시간 데이터 구조체
+type PlayerLatencyData struct {
+	PlayerIndex int   `json:"playerIndex"` // 플레이어 인덱스
+	RttMs       int64 `json:"rttMs"`       // 왕복 지연 시간 (밀리초, 측정 전이면 0)
+	JitterMs    int64 `json:"jitterMs"`    // 왕복 지연 시간의 흔들림 (밀리초)
+}
+
+// 지연 시간 경고 데이터 구조체 (왕복 지연 시간이 경고 기준을 넘으면 해당 클라이언트에게 전송)
+type LatencyWarningData struct {
+	RttMs    int64 `json:"rttMs"`    // 왕복 지연 시간 (밀리초)
+	JitterMs int64 `json:"jitterMs"` // 왕복 지연 시간의 흔들림 (밀리초)
+	WarnMs   int   `json:"warnMs"`   // 경고 기준 (밀리초)
+	DropMs   int   `json:"dropMs"`   // 연결 종료 기준 (밀리초, 0이면 끊지 않음)
+}
+
 // 게임 종료 데이터 구조체
 type EndGameData struct {
 	PlayerCards []int `json:"playerCards"` // 각 플레이어의 카드 개수 배열
@@ -167,7 +361,7 @@ type EndGameData struct {
 
 // 감정표현 요청 데이터 구조체
 type RequestEmotionData struct {
-	EmotionType int `json:"emotionType"` // 감정표현 타입
+	EmotionType int `json:"emotionType" label:"감정표현 타입" validate:"required,min=0"` // 감정표현 타입
 }
 
 // 감정표현 응답 데이터 구조체
@@ -178,12 +372,69 @@ type ResponseEmotionData struct {
 
 // 계정 생성 요청 데이터 구조체
 type RequestCreateAccountData struct {
-	ID       string `json:"id"`       // 아이디
-	Password string `json:"password"` // 비밀번호
-	Nickname string `json:"nickname"` // 닉네임
+	ID       string `json:"id" label:"ID" validate:"required,nonempty,max=10"`             // 아이디
+	Password string `json:"password" label:"Password" validate:"required,nonempty,max=10"` // 비밀번호
+	Nickname string `json:"nickname" label:"Nickname" validate:"required,nonempty,max=10"` // 닉네임 (금칙어는 validateNickname으로 검사)
 }
 
 // 계정 생성 응답 데이터 구조체
 type ResponseCreateAccountData struct {
 	ID string `json:"id"` // 생성된 계정의 아이디
 }
+
+// 로그인 요청 데이터 구조체
+type RequestLoginData struct {
+	ID       string `json:"id" label:"ID" validate:"required,nonempty"`             // 아이디
+	Password string `json:"password" label:"Password" validate:"required,nonempty"` // 비밀번호
+}
+
+// 로그인 응답 데이터 구조체
+type ResponseLoginData struct {
+	ID           string `json:"id"`           // 로그인한 계정의 아이디
+	Nickname     string `json:"nickname"`     // 로그인한 계정의 닉네임
+	SessionToken string `json:"sessionToken"` // 다음 연결에서 로그인 상태를 복원할 세션 토큰
+}
+
+// 닉네임 변경 요청 데이터 구조체
+type RequestChangeNickNameData struct {
+	Nickname string `json:"nickname" label:"Nickname" validate:"required,nonempty,max=10"` // 새 닉네임 (금칙어는 validateNickname으로 검사)
+}
+
+// 닉네임 변경 응답 데이터 구조체 (방에 앉아 있으면 방 전체에 전송)
+type ChangeNickNameData struct {
+	PlayerID    string   `json:"playerId"`    // 닉네임을 바꾼 플레이어 ID
+	Nickname    string   `json:"nickname"`    // 새 닉네임
+	PlayerIndex int      `json:"playerIndex"` // 좌석 인덱스 (게임 시작 전이거나 방에 없으면 -1)
+	PlayerNames []string `json:"playerNames"` // 좌석 순서대로의 플레이어 이름 (게임 시작 전이면 빈 배열)
+}
+
+// 세션 토큰 로그인 요청 데이터 구조체
+type RequestSessionLoginData struct {
+	SessionToken string `json:"sessionToken" label:"세션 토큰" validate:"required,nonempty"` // 로그인 응답으로 받은 세션 토큰
+}
+
+// 전적 조회 요청 데이터 구조체
+type RequestPlayerStatsData struct {
+	AccountID string `json:"accountId" label:"계정 ID" validate:"max=10"` // 조회할 계정 아이디 (비어있으면 내 전적)
+}
+
+// 전적 조회 응답 데이터 구조체
+type PlayerStatsData struct {
+	AccountID    string  `json:"accountId"`    // 계정 아이디
+	Nickname     string  `json:"nickname"`     // 계정 닉네임
+	Rating       int     `json:"rating"`       // 현재 레이팅 (반올림)
+	RatedGames   int     `json:"ratedGames"`   // 레이팅에 반영된 게임 수
+	Matches      int     `json:"matches"`      // 끝까지 플레이한 게임 수
+	Wins         int     `json:"wins"`         // 1등 횟수
+	WinRate      float64 `json:"winRate"`      // 승률 (0-1, 게임 기록이 없으면 0)
+	AverageRank  float64 `json:"averageRank"`  // 평균 순위 (게임 기록이 없으면 0)
+	BellHits     int     `json:"bellHits"`     // 올바르게 벨을 누른 횟수
+	BellMisses   int     `json:"bellMisses"`   // 잘못 벨을 누른 횟수
+	BellAccuracy float64 `json:"bellAccuracy"` // 벨 정확도 (0-1, 벨을 누른 적이 없으면 0)
+}
+
+// 순위표 조회 요청 데이터 구조체 (응답 data는 leaderboard.Board)
+type RequestLeaderboardData struct {
+	Category string `json:"category"` // 순위표 종류 (wins, rating, bellAccuracy, fastestRing, 비어있으면 wins)
+	Period   string `json:"period"`   // 집계 기간 (daily, weekly, allTime, 비어있으면 allTime)
+}
@@ -0,0 +1,147 @@
+package socket
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// 요청 데이터 검증 실패 사유 코드 (에러 응답 data의 reason)
+const (
+	PayloadInvalidFormat = "invalidFormat" // data가 JSON 객체가 아님
+	PayloadInvalidType   = "invalidType"   // 필드 타입이 맞지 않음
+	PayloadRequired      = "required"      // 필수 필드가 없음
+	PayloadEmpty         = "empty"         // 빈 문자열
+	PayloadTooLong       = "tooLong"       // 최대 길이 초과
+	PayloadOutOfRange    = "outOfRange"    // 숫자 범위 벗어남
+)
+
+// 요청 데이터 검증 에러
+type PayloadError struct {
+	Field   string // 문제가 된 필드 (JSON 이름, data 전체 문제면 빈 문자열)
+	Reason  string // 실패 사유 코드
+	Message string // 로그와 사용자 안내용 메시지
+}
+
+func (e *PayloadError) Error() string {
+	return e.Message
+}
+
+// 요청 데이터 구조체 검증 규칙 (필드의 validate 태그로 선언)
+//   - required: 필드가 있어야 함
+//   - nonempty: 빈 문자열이면 안 됨
+//   - max=N: 문자열 글자 수(한글도 한 글자) 또는 숫자의 최대값
+//   - min=N: 숫자의 최소값
+//
+// label 태그는 에러 메시지에 쓰는 필드 이름 (없으면 JSON 이름)
+
+// 요청 처리기에 등록된 구조체로 data를 디코딩하고 검증
+func decodeRequestPayload(request *RequestPacket, newPayload func() interface{}) (interface{}, *PayloadError) {
+	// 필드 존재 여부를 확인하기 위해 먼저 객체로 읽음
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(request.Data, &fields); err != nil || fields == nil {
+		return nil, &PayloadError{Reason: PayloadInvalidFormat, Message: "잘못된 데이터 형식입니다"}
+	}
+
+	payload := newPayload()
+	if err := json.Unmarshal(request.Data, payload); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			field := typeErr.Field
+			return nil, &PayloadError{
+				Field:   field,
+				Reason:  PayloadInvalidType,
+				Message: fmt.Sprintf("잘못된 형식입니다: %s", fieldLabel(payload, field)),
+			}
+		}
+		return nil, &PayloadError{Reason: PayloadInvalidFormat, Message: "잘못된 데이터 형식입니다"}
+	}
+
+	if err := validatePayload(payload, fields); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// validate 태그에 선언된 규칙으로 구조체 필드 검증
+func validatePayload(payload interface{}, fields map[string]json.RawMessage) *PayloadError {
+	value := reflect.ValueOf(payload).Elem()
+	structType := value.Type()
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		rules := field.Tag.Get("validate")
+		if rules == "" {
+			continue
+		}
+
+		name := jsonFieldName(field)
+		label := field.Tag.Get("label")
+		if label == "" {
+			label = name
+		}
+		raw, present := fields[name]
+		present = present && !bytes.Equal(raw, []byte("null"))
+		fieldValue := value.Field(i)
+
+		for _, rule := range strings.Split(rules, ",") {
+			ruleName, arg, _ := strings.Cut(rule, "=")
+			limit, _ := strconv.Atoi(arg)
+
+			switch ruleName {
+			case "required":
+				if !present {
+					return &PayloadError{Field: name, Reason: PayloadRequired, Message: fmt.Sprintf("필수 항목이 없습니다: %s", label)}
+				}
+			case "nonempty":
+				if fieldValue.Kind() == reflect.String && fieldValue.String() == "" {
+					return &PayloadError{Field: name, Reason: PayloadEmpty, Message: fmt.Sprintf("비어있을 수 없는 항목입니다: %s", label)}
+				}
+			case "max":
+				switch fieldValue.Kind() {
+				case reflect.String:
+					if utf8.RuneCountInString(fieldValue.String()) > limit {
+						return &PayloadError{Field: name, Reason: PayloadTooLong, Message: fmt.Sprintf("최대 길이(%d자)를 넘었습니다: %s", limit, label)}
+					}
+				case reflect.Int:
+					if fieldValue.Int() > int64(limit) {
+						return &PayloadError{Field: name, Reason: PayloadOutOfRange, Message: fmt.Sprintf("최대값(%d)보다 큽니다: %s", limit, label)}
+					}
+				}
+			case "min":
+				if fieldValue.Kind() == reflect.Int && fieldValue.Int() < int64(limit) {
+					return &PayloadError{Field: name, Reason: PayloadOutOfRange, Message: fmt.Sprintf("최소값(%d)보다 작습니다: %s", limit, label)}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// 구조체 필드의 JSON 이름
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// JSON 필드 이름에 해당하는 label (없으면 JSON 이름)
+func fieldLabel(payload interface{}, name string) string {
+	structType := reflect.TypeOf(payload).Elem()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if jsonFieldName(field) == name {
+			if label := field.Tag.Get("label"); label != "" {
+				return label
+			}
+		}
+	}
+	return name
+}
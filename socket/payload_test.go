@@ -0,0 +1,85 @@
+package socket
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDecodeRequestPayload(t *testing.T) {
+	newAccount := func() interface{} { return &RequestCreateAccountData{} }
+	newEmotion := func() interface{} { return &RequestEmotionData{} }
+	newRoom := func() interface{} { return &RequestCreateRoomData{} }
+
+	tests := []struct {
+		name       string
+		newPayload func() interface{}
+		data       string
+		wantField  string
+		wantReason string // 비어있으면 검증 통과
+	}{
+		{"올바른 데이터", newAccount, `{"id":"hong","password":"pw","nickname":"홍길동"}`, "", ""},
+		{"필수 항목 없음", newAccount, `{"password":"pw","nickname":"홍길동"}`, "id", PayloadRequired},
+		{"null은 없는 것과 같음", newAccount, `{"id":null,"password":"pw","nickname":"홍길동"}`, "id", PayloadRequired},
+		{"빈 문자열", newAccount, `{"id":"hong","password":"","nickname":"홍길동"}`, "password", PayloadEmpty},
+		{"최대 길이는 글자 수로 셈", newAccount, `{"id":"hong","password":"pw","nickname":"가나다라마바사아자차"}`, "", ""},
+		{"최대 길이 초과", newAccount, `{"id":"hong","password":"pw","nickname":"가나다라마바사아자차카"}`, "nickname", PayloadTooLong},
+		{"영문 최대 길이 초과", newAccount, `{"id":"abcdefghijk","password":"pw","nickname":"n"}`, "id", PayloadTooLong},
+		{"필드 타입이 다름", newAccount, `{"id":5,"password":"pw","nickname":"n"}`, "id", PayloadInvalidType},
+		{"객체가 아님", newAccount, `["hong"]`, "", PayloadInvalidFormat},
+		{"문자열 data", newAccount, `"hong"`, "", PayloadInvalidFormat},
+		{"숫자 최소값", newEmotion, `{"emotionType":0}`, "", ""},
+		{"숫자 최소값 미만", newEmotion, `{"emotionType":-1}`, "emotionType", PayloadOutOfRange},
+		{"숫자 필드에 문자열", newEmotion, `{"emotionType":"1"}`, "emotionType", PayloadInvalidType},
+		{"선택 항목 생략", newRoom, `{}`, "", ""},
+		{"방 이름 글자 수 초과", newRoom, `{"name":"가나다라마바사아자차카타파하가나다라마바사"}`, "name", PayloadTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := &RequestPacket{Signal: RequestCreateAccount, Data: json.RawMessage(tt.data)}
+			payload, err := decodeRequestPayload(request, tt.newPayload)
+
+			if tt.wantReason == "" {
+				if err != nil {
+					t.Fatalf("검증 실패: %+v", err)
+				}
+				if payload == nil {
+					t.Fatal("payload가 nil")
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("검증 통과, want reason %q", tt.wantReason)
+			}
+			if err.Reason != tt.wantReason || err.Field != tt.wantField {
+				t.Errorf("field, reason = %q, %q, want %q, %q", err.Field, err.Reason, tt.wantField, tt.wantReason)
+			}
+			if err.Message == "" {
+				t.Error("에러 메시지가 비어있음")
+			}
+		})
+	}
+}
+
+func TestPayloadErrorResponse(t *testing.T) {
+	request := &RequestPacket{Signal: RequestCreateAccount, Data: json.RawMessage(`{"id":true,"password":"pw","nickname":"n"}`)}
+	_, payloadErr := decodeRequestPayload(request, func() interface{} { return &RequestCreateAccountData{} })
+	if payloadErr == nil {
+		t.Fatal("검증 통과")
+	}
+
+	response := NewPayloadErrorResponse(RequestCreateAccount, payloadErr)
+	if response.Signal != RequestCreateAccount || response.Code != CodeError {
+		t.Errorf("signal, code = %d, %d, want %d, %d", response.Signal, response.Code, RequestCreateAccount, CodeError)
+	}
+
+	data, ok := response.Data.(*ErrorData)
+	if !ok {
+		t.Fatalf("data 타입 = %T", response.Data)
+	}
+	want := ErrorData{ErrorCode: ErrCodeInvalidPayload, Message: "잘못된 형식입니다: ID", Field: "id", Reason: PayloadInvalidType}
+	if *data != want {
+		t.Errorf("data = %+v, want %+v", *data, want)
+	}
+}
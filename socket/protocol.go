@@ -0,0 +1,114 @@
+package socket
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"main/config"
+)
+
+// 프로토콜 버전 (시그널이나 요청/응답 데이터 형식이 호환되지 않게 바뀌면 올림)
+const (
+	ProtocolVersion    = 1 // 서버가 사용하는 프로토콜 버전
+	MinProtocolVersion = 1 // 서버가 받아주는 가장 낮은 클라이언트 프로토콜 버전
+)
+
+// 클라이언트와 서버가 함께 쓸 수 있는 부가 기능
+const (
+	CapabilitySessionToken = "sessionToken" // 세션 토큰 로그인 (RequestSessionLogin, 연결 시 /ws?token=은 핸드셰이크 전이라 항상 허용)
+	CapabilityReconnect    = "reconnect"    // 게임 중 재접속 (RequestReconnect)
+	CapabilitySpectate     = "spectate"     // 관전 (RequestSpectateRoom)
+	CapabilityBots         = "bots"         // 봇 추가/제거 (RequestAddBot, RequestRemoveBot)
+)
+
+// 서버가 지원하는 부가 기능 목록
+var serverCapabilities = []string{
+	CapabilitySessionToken,
+	CapabilityReconnect,
+	CapabilitySpectate,
+	CapabilityBots,
+}
+
+// 핸드셰이크를 보내지 않은 클라이언트가 쓸 수 있는 부가 기능 (프로토콜 버전 1에 포함된 기능)
+var baselineCapabilities = map[string]bool{
+	CapabilitySessionToken: true,
+	CapabilityReconnect:    true,
+	CapabilitySpectate:     true,
+	CapabilityBots:         true,
+}
+
+// 핸드셰이크 처리 (클라이언트 프로토콜 버전과 부가 기능을 확인하고 서버 정보 응답)
+// 핸드셰이크를 보내지 않은 클라이언트는 MinProtocolVersion으로 간주하고 기본 부가 기능을 사용
+func (h *Handler) handleHello(client *Client, helloData *RequestHelloData) {
+	// 서버가 받아줄 수 없는 오래된 클라이언트는 업데이트 안내 후 연결 종료
+	if helloData.ProtocolVersion < MinProtocolVersion {
+		message := fmt.Sprintf("클라이언트 업데이트가 필요합니다 (클라이언트 프로토콜 버전: %d, 최소 지원 버전: %d)", helloData.ProtocolVersion, MinProtocolVersion)
+		h.sendErrorWithSignal(client, RequestHello, ErrCodeUpgradeRequired, message)
+		log.Printf("호환되지 않는 클라이언트 연결 종료: %s - 프로토콜 버전: %d", client.ID, helloData.ProtocolVersion)
+
+		// 더 이상 요청을 처리하지 않고 연결 해제 (writePump가 에러 패킷을 마저 보낸 뒤 연결을 닫음)
+		client.disconnect()
+		return
+	}
+
+	// 클라이언트가 더 새 버전이면 서버 버전으로 맞춤
+	protocolVersion := helloData.ProtocolVersion
+	if protocolVersion > ProtocolVersion {
+		protocolVersion = ProtocolVersion
+	}
+
+	// 양쪽이 모두 지원하는 부가 기능만 사용
+	requested := make(map[string]bool, len(helloData.Capabilities))
+	for _, capability := range helloData.Capabilities {
+		requested[capability] = true
+	}
+	capabilities := make(map[string]bool)
+	agreed := []string{}
+	for _, capability := range serverCapabilities {
+		if requested[capability] {
+			capabilities[capability] = true
+			agreed = append(agreed, capability)
+		}
+	}
+
+	client.mu.Lock()
+	client.protocolVersion = protocolVersion
+	client.capabilities = capabilities
+	client.mu.Unlock()
+
+	log.Printf("핸드셰이크 완료: %s - 프로토콜 버전: %d, 부가 기능: %v", client.ID, protocolVersion, agreed)
+
+	responseData := &ResponseHelloData{
+		ProtocolVersion:    protocolVersion,
+		ServerVersion:      ProtocolVersion,
+		MinProtocolVersion: MinProtocolVersion,
+		Signals:            h.supportedSignals(),
+		Capabilities:       agreed,
+		ServerCapabilities: serverCapabilities,
+		Encoding:           client.encoding.String(),
+		Config:             *config.GetDefaultConfig(),
+	}
+	response := NewSuccessResponse(ResponseHello, responseData)
+	h.sendToClient(client, response)
+}
+
+// 부가 기능을 쓸 수 있는지 확인 (핸드셰이크 전이면 기본 부가 기능, 핸드셰이크 후에는 협상한 기능)
+func (c *Client) hasCapability(capability string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.protocolVersion == 0 {
+		return baselineCapabilities[capability]
+	}
+	return c.capabilities[capability]
+}
+
+// 등록된 요청 시그널 목록 (오름차순)
+func (h *Handler) supportedSignals() []int {
+	signals := make([]int, 0, len(h.signalHandlers))
+	for signal := range h.signalHandlers {
+		signals = append(signals, signal)
+	}
+	sort.Ints(signals)
+	return signals
+}
@@ -0,0 +1,202 @@
+package socket
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// 요청을 처리하기 위해 클라이언트가 갖춰야 하는 상태 (뒤로 갈수록 앞의 조건을 포함)
+type ClientState int
+
+const (
+	StateConnected ClientState = iota // 연결만 되어 있으면 됨
+	StateLoggedIn                     // 로그인 필요
+	StateInRoom                       // 플레이어로 방에 참여 중
+	StateInGame                       // 게임이 시작된 방에 플레이어로 참여 중
+)
+
+// 요청 시그널 처리기 (시그널, 요청 데이터 구조체, 필요한 클라이언트 상태, 요청 횟수 제한)
+type SignalHandler struct {
+	Signal     int
+	State      ClientState
+	RateLimit  int           // Interval 동안 허용하는 요청 수 (0이면 제한 없음)
+	Interval   time.Duration // 요청 횟수 제한 구간
+	Capability string        // 핸드셰이크에서 협상해야 쓸 수 있는 부가 기능 (비어있으면 누구나 사용)
+	newPayload func() interface{}
+	handle     func(h *Handler, client *Client, payload interface{})
+}
+
+// 요청 데이터를 쓰지 않는 처리기 생성
+func NewSignalHandler(signal int, state ClientState, handle func(h *Handler, client *Client)) *SignalHandler {
+	return &SignalHandler{
+		Signal: signal,
+		State:  state,
+		handle: func(h *Handler, client *Client, _ interface{}) {
+			handle(h, client)
+		},
+	}
+}
+
+// 요청 데이터를 T 구조체로 디코딩하고 검증한 뒤 넘겨주는 처리기 생성 (검증 규칙은 T의 validate 태그)
+func NewPayloadHandler[T any](signal int, state ClientState, handle func(h *Handler, client *Client, payload *T)) *SignalHandler {
+	return &SignalHandler{
+		Signal: signal,
+		State:  state,
+		newPayload: func() interface{} {
+			return new(T)
+		},
+		handle: func(h *Handler, client *Client, payload interface{}) {
+			handle(h, client, payload.(*T))
+		},
+	}
+}
+
+// 요청 횟수 제한 설정 (interval 동안 count번까지 허용)
+func (s *SignalHandler) Limit(count int, interval time.Duration) *SignalHandler {
+	s.RateLimit = count
+	s.Interval = interval
+	return s
+}
+
+// 핸드셰이크에서 부가 기능을 협상한 클라이언트만 쓸 수 있도록 설정
+func (s *SignalHandler) Requires(capability string) *SignalHandler {
+	s.Capability = capability
+	return s
+}
+
+// 요청 처리기 등록 (같은 시그널을 두 번 등록하면 panic)
+func (h *Handler) Handle(handler *SignalHandler) {
+	if _, exists := h.signalHandlers[handler.Signal]; exists {
+		panic(fmt.Sprintf("이미 등록된 요청 signal: %d", handler.Signal))
+	}
+	h.signalHandlers[handler.Signal] = handler
+}
+
+// 서버가 처리하는 모든 요청 등록
+func (h *Handler) registerSignalHandlers() {
+	// 연결
+	h.Handle(NewSignalHandler(RequestPing, StateConnected, (*Handler).handlePing).Limit(10, time.Second))
+	h.Handle(NewPayloadHandler(RequestHello, StateConnected, (*Handler).handleHello).Limit(3, 10*time.Second))
+
+	// 방 관리
+	h.Handle(NewSignalHandler(RequestEnterRoom, StateLoggedIn, (*Handler).handleEnterRoom).Limit(5, 10*time.Second))
+	h.Handle(NewSignalHandler(RequestLeaveRoom, StateConnected, (*Handler).handleLeaveRoom))
+	h.Handle(NewPayloadHandler(RequestCreateRoom, StateLoggedIn, (*Handler).handleCreateRoom).Limit(5, 10*time.Second))
+	h.Handle(NewSignalHandler(RequestRoomList, StateConnected, (*Handler).handleRoomList).Limit(5, time.Second))
+	h.Handle(NewPayloadHandler(RequestJoinRoom, StateLoggedIn, (*Handler).handleJoinRoom).Limit(5, 10*time.Second))
+	h.Handle(NewPayloadHandler(RequestReconnect, StateConnected, (*Handler).handleReconnect).Limit(5, 10*time.Second).Requires(CapabilityReconnect))
+	h.Handle(NewSignalHandler(RequestSnapshot, StateConnected, (*Handler).handleSnapshot).Limit(5, time.Second))
+	h.Handle(NewPayloadHandler(RequestSpectateRoom, StateConnected, (*Handler).handleSpectateRoom).Limit(5, 10*time.Second).Requires(CapabilitySpectate))
+	h.Handle(NewSignalHandler(RequestStartGame, StateInRoom, (*Handler).handleStartGame))
+	h.Handle(NewSignalHandler(RequestToggleReady, StateInRoom, (*Handler).handleToggleReady).Limit(5, time.Second))
+	h.Handle(NewPayloadHandler(RequestAddBot, StateInRoom, (*Handler).handleAddBot).Limit(5, time.Second).Requires(CapabilityBots))
+	h.Handle(NewPayloadHandler(RequestRemoveBot, StateInRoom, (*Handler).handleRemoveBot).Limit(5, time.Second).Requires(CapabilityBots))
+	h.Handle(NewSignalHandler(RequestJoinQueue, StateLoggedIn, (*Handler).handleJoinQueue).Limit(3, 10*time.Second))
+	h.Handle(NewSignalHandler(RequestLeaveQueue, StateLoggedIn, (*Handler).handleLeaveQueue))
+
+	// 게임 진행
+	h.Handle(NewSignalHandler(RequestReadyGame, StateInGame, (*Handler).handleReadyGame))
+	h.Handle(NewSignalHandler(RequestRingBell, StateInGame, (*Handler).handleRingBell).Limit(5, time.Second))
+	h.Handle(NewPayloadHandler(RequestEmotion, StateInGame, (*Handler).handleEmotion).Limit(5, time.Second))
+
+	// 계정
+	h.Handle(NewPayloadHandler(RequestCreateAccount, StateConnected, (*Handler).handleCreateAccount).Limit(3, time.Minute))
+	h.Handle(NewPayloadHandler(RequestLogin, StateConnected, (*Handler).handleLogin).Limit(5, time.Minute))
+	h.Handle(NewPayloadHandler(RequestChangeNickName, StateLoggedIn, (*Handler).handleChangeNickName).Limit(3, time.Minute))
+	h.Handle(NewPayloadHandler(RequestSessionLogin, StateConnected, (*Handler).handleSessionLogin).Limit(5, time.Minute).Requires(CapabilitySessionToken))
+	h.Handle(NewSignalHandler(RequestLogout, StateLoggedIn, (*Handler).handleLogout))
+	h.Handle(NewPayloadHandler(RequestPlayerStats, StateLoggedIn, (*Handler).handlePlayerStats).Limit(5, 10*time.Second))
+	h.Handle(NewPayloadHandler(RequestLeaderboard, StateConnected, (*Handler).handleLeaderboard).Limit(5, 10*time.Second))
+}
+
+// 등록된 처리기로 요청 전달 (상태, 부가 기능, 요청 횟수, 요청 데이터를 차례로 확인)
+func (h *Handler) dispatch(client *Client, request *RequestPacket) {
+	handler, exists := h.signalHandlers[request.Signal]
+	if !exists {
+		log.Printf("알 수 없는 요청 signal: %d", request.Signal)
+		h.sendErrorWithSignal(client, request.Signal, ErrCodeUnknownSignal, "알 수 없는 요청입니다")
+		return
+	}
+
+	if errorCode, message := checkClientState(client, handler.State); message != "" {
+		h.sendErrorWithSignal(client, request.Signal, errorCode, message)
+		return
+	}
+
+	if handler.Capability != "" && !client.hasCapability(handler.Capability) {
+		message := fmt.Sprintf("핸드셰이크에서 %s 기능을 협상해야 사용할 수 있는 요청입니다", handler.Capability)
+		h.sendErrorWithSignal(client, request.Signal, ErrCodeCapabilityRequired, message)
+		return
+	}
+
+	if handler.RateLimit > 0 {
+		// 제한을 넘긴 요청마다 에러를 보내면 전송 버퍼가 가득 차므로 구간마다 한 번만 알리고 나머지는 무시
+		allowed, notify := client.allowRequest(request.Signal, handler.RateLimit, handler.Interval)
+		if !allowed {
+			if notify {
+				h.sendErrorWithSignal(client, request.Signal, ErrCodeRateLimited, "요청이 너무 많습니다. 잠시 후 다시 시도하세요")
+			}
+			return
+		}
+	}
+
+	var payload interface{}
+	if handler.newPayload != nil {
+		var payloadErr *PayloadError
+		payload, payloadErr = decodeRequestPayload(request, handler.newPayload)
+		if payloadErr != nil {
+			log.Printf("요청 데이터 검증 실패 (signal: %d): %s", request.Signal, payloadErr.Message)
+			h.sendToClient(client, NewPayloadErrorResponse(request.Signal, payloadErr))
+			return
+		}
+	}
+
+	handler.handle(h, client, payload)
+}
+
+// 클라이언트가 요구 상태를 갖췄는지 확인 (갖추지 못했으면 에러 코드와 메시지 반환)
+func checkClientState(client *Client, state ClientState) (ErrorCode, string) {
+	if state >= StateLoggedIn && client.AccountID == "" {
+		return ErrCodeLoginRequired, "로그인이 필요합니다"
+	}
+	if state >= StateInRoom && (!client.IsInRoom || client.Room == nil) {
+		return ErrCodeNotInRoom, "방에 참여하지 않은 상태입니다"
+	}
+	if state >= StateInGame && !client.Room.game.IsGameStarted() {
+		return ErrCodeGameNotStarted, "게임이 시작되지 않은 상태입니다"
+	}
+	return 0, ""
+}
+
+// 시그널별 요청 횟수 제한 구간
+type rateWindow struct {
+	start    time.Time
+	count    int
+	rejected bool // 이 구간에서 이미 제한 에러를 보냈는지
+}
+
+// 요청 횟수 제한 확인 (interval 구간마다 count번까지 허용)
+// 허용하지 않는 요청이면 구간에서 처음 거절할 때만 notify가 true
+func (c *Client) allowRequest(signal, count int, interval time.Duration) (allowed bool, notify bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.rateWindows == nil {
+		c.rateWindows = make(map[int]*rateWindow)
+	}
+
+	now := time.Now()
+	window, exists := c.rateWindows[signal]
+	if !exists || now.Sub(window.start) >= interval {
+		c.rateWindows[signal] = &rateWindow{start: now, count: 1}
+		return true, false
+	}
+	if window.count >= count {
+		notify = !window.rejected
+		window.rejected = true
+		return false, notify
+	}
+	window.count++
+	return true, false
+}
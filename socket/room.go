@@ -0,0 +1,300 @@
+package socket
+
+import (
+	"sync"
+	"time"
+
+	"main/config"
+	"main/game"
+)
+
+// 방 참여 코드 길이
+const roomCodeLength = 6
+
+// 방 정보 구조체 (게임 규칙은 game.Room 엔진이 담당하고, 이 구조체는 네트워크 관련 상태만 관리)
+type Room struct {
+	mu        sync.Mutex // 타이머와 감정표현 상태 보호 (카드 공개/게임 종료 처리를 직렬화)
+	id        string     // 방 ID
+	name      string     // 방 이름
+	isPrivate bool       // 비공개 방 여부 (방 목록에 노출되지 않음)
+	code      string     // 비공개 방 참여 코드
+	// 게임 시작 방식
+	startPolicy string // 게임 시작 방식 (config.StartPolicy*)
+	// 게임 규칙 엔진과 방의 게임 설정 (방 생성 후 바뀌지 않음)
+	game       *game.Room
+	gameConfig config.GameConfig
+	// 타이머
+	cardTimer *time.Timer // 카드 공개 타이머
+	gameTimer *time.Timer // 게임 제한시간 타이머
+	// 카운트다운 방식에서 최소 인원이 모였을 때 게임을 시작하는 타이머
+	startTimer *time.Timer
+	// 모든 플레이어 연결 해제 시 재접속을 기다렸다가 방을 정리하는 타이머
+	abandonTimer *time.Timer
+	gameEndsAt   time.Time // 게임 제한시간이 끝나는 시각
+	// 첫 벨 누름 이후 판정 구간 동안 모은 벨 누름과 판정 타이머
+	bellPresses []*bellPress
+	bellTimer   *time.Timer
+	// 감정표현 관련 상태
+	lastEmotionTimes map[string]time.Time // 각 클라이언트별 마지막 감정표현 시간
+	// 방에 연결된 클라이언트 (브로드캐스트 대상)
+	membersMu  sync.RWMutex
+	hostID     string                // 방장 플레이어 ID (방장이 나가면 다른 플레이어에게 넘어감)
+	clients    map[*Client]bool      // 좌석에 앉은 플레이어의 연결
+	spectators map[*Client]bool      // 읽기 전용 관전자 (플레이어 수에 포함되지 않음)
+	bots       map[string]*botPlayer // 좌석을 채운 봇 (봇 ID -> 봇)
+	// 게임 시작 시 좌석에 앉은 플레이어의 로그인 계정 (플레이어 ID -> 계정 ID, 전적 저장용)
+	seatAccounts map[string]string
+}
+
+// 새로운 방 생성
+func newRoom(id, name string, isPrivate bool, startPolicy string, gameConfig *config.GameConfig) *Room {
+	return &Room{
+		id:               id,
+		name:             name,
+		isPrivate:        isPrivate,
+		startPolicy:      startPolicy,
+		game:             game.NewRoom(*gameConfig),
+		gameConfig:       *gameConfig,
+		lastEmotionTimes: make(map[string]time.Time),
+		clients:          make(map[*Client]bool),
+		spectators:       make(map[*Client]bool),
+		bots:             make(map[string]*botPlayer),
+	}
+}
+
+// 방 정보 조회
+func (r *Room) Info() RoomInfoData {
+	return RoomInfoData{
+		RoomID:        r.id,
+		Name:          r.name,
+		PlayerCount:   r.game.PlayerCount(),
+		MinPlayers:    r.gameConfig.MinPlayers,
+		MaxPlayers:    r.gameConfig.MaxPlayers,
+		IsGameStarted: r.game.IsGameStarted(),
+		IsPrivate:     r.isPrivate,
+		StartPolicy:   r.startPolicy,
+	}
+}
+
+// 남은 게임 제한시간 (밀리초, 타이머가 시작되지 않았으면 0)
+func (r *Room) remainingTimeMs() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.gameEndsAt.IsZero() {
+		return 0
+	}
+	remaining := time.Until(r.gameEndsAt).Milliseconds()
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// 방 상태 스냅샷 생성 (playerID의 좌석 인덱스를 MyIndex로 설정)
+func (r *Room) snapshot(playerID string) *RoomSnapshotData {
+	state := r.game.Snapshot()
+
+	myIndex := -1
+	for i, id := range state.PlayerIDs {
+		if id == playerID {
+			myIndex = i
+			break
+		}
+	}
+
+	return &RoomSnapshotData{
+		RoomID:             r.id,
+		IsGameStarted:      state.IsGameStarted,
+		IsCardGameStarted:  state.IsCardGameStarted,
+		PlayerCount:        len(state.PlayerIDs),
+		PlayerNames:        state.PlayerNames,
+		PlayerConnected:    state.PlayerActive,
+		MyIndex:            myIndex,
+		PlayerCards:        state.PlayerCards,
+		OpenCards:          state.OpenCards,
+		PublicFruitIndexes: state.PublicFruitIndexes,
+		PublicFruitCounts:  state.PublicFruitCounts,
+		CurrentPlayerIndex: state.CurrentPlayerIndex,
+		BellRung:           state.BellRung,
+		IsTimeExpired:      state.IsTimeExpired,
+		RemainingTimeMs:    r.remainingTimeMs(),
+		Config:             r.gameConfig,
+	}
+}
+
+// 카드 공개/게임 제한시간/시작 카운트다운/벨 판정 타이머 정지 (뮤텍스가 이미 잠겨있는 경우를 위한 내부 함수)
+func (r *Room) stopTimersLocked() {
+	if r.cardTimer != nil {
+		r.cardTimer.Stop()
+		r.cardTimer = nil
+	}
+	if r.gameTimer != nil {
+		r.gameTimer.Stop()
+		r.gameTimer = nil
+	}
+	if r.abandonTimer != nil {
+		r.abandonTimer.Stop()
+		r.abandonTimer = nil
+	}
+	if r.startTimer != nil {
+		r.startTimer.Stop()
+		r.startTimer = nil
+	}
+	if r.bellTimer != nil {
+		r.bellTimer.Stop()
+		r.bellTimer = nil
+	}
+	r.bellPresses = nil
+}
+
+// 방에 연결된 클라이언트 추가 (방장이 없으면 방장으로 지정)
+func (r *Room) addClient(client *Client) {
+	r.membersMu.Lock()
+	defer r.membersMu.Unlock()
+	r.clients[client] = true
+	if r.hostID == "" {
+		r.hostID = client.ID
+	}
+}
+
+// 방에 연결된 클라이언트 제거 (방장이 나가면 남은 플레이어에게 방장을 넘김)
+func (r *Room) removeClient(client *Client) {
+	r.membersMu.Lock()
+	defer r.membersMu.Unlock()
+	delete(r.clients, client)
+
+	if r.hostID == client.ID {
+		r.hostID = ""
+		for c := range r.clients {
+			r.hostID = c.ID
+			break
+		}
+	}
+}
+
+// 방장인지 확인
+func (r *Room) isHost(playerID string) bool {
+	r.membersMu.RLock()
+	defer r.membersMu.RUnlock()
+	return r.hostID == playerID
+}
+
+// 방에 연결된 클라이언트 목록 조회
+func (r *Room) memberClients() []*Client {
+	r.membersMu.RLock()
+	defer r.membersMu.RUnlock()
+
+	clients := make([]*Client, 0, len(r.clients))
+	for client := range r.clients {
+		clients = append(clients, client)
+	}
+	return clients
+}
+
+// 게임 시작 시 좌석에 앉은 플레이어들의 로그인 계정 기록 (게임 중 연결이 끊겨도 전적을 계정에 남기기 위함)
+func (r *Room) recordSeatAccounts() {
+	r.membersMu.Lock()
+	defer r.membersMu.Unlock()
+
+	r.seatAccounts = make(map[string]string, len(r.clients))
+	for client := range r.clients {
+		if client.AccountID != "" {
+			r.seatAccounts[client.ID] = client.AccountID
+		}
+	}
+}
+
+// 플레이어 ID에 해당하는 로그인 계정 (봇이나 로그인하지 않은 플레이어는 빈 문자열)
+func (r *Room) seatAccount(playerID string) string {
+	r.membersMu.RLock()
+	defer r.membersMu.RUnlock()
+	return r.seatAccounts[playerID]
+}
+
+// 관전자 추가 (최대 관전자 수를 넘으면 false)
+func (r *Room) addSpectator(client *Client, maxSpectators int) bool {
+	r.membersMu.Lock()
+	defer r.membersMu.Unlock()
+
+	if len(r.spectators) >= maxSpectators {
+		return false
+	}
+	r.spectators[client] = true
+	return true
+}
+
+// 관전자 제거
+func (r *Room) removeSpectator(client *Client) {
+	r.membersMu.Lock()
+	defer r.membersMu.Unlock()
+	delete(r.spectators, client)
+}
+
+// 관전자 목록 조회
+func (r *Room) spectatorClients() []*Client {
+	r.membersMu.RLock()
+	defer r.membersMu.RUnlock()
+
+	clients := make([]*Client, 0, len(r.spectators))
+	for client := range r.spectators {
+		clients = append(clients, client)
+	}
+	return clients
+}
+
+// 모든 관전자의 관전 상태 해제 (방이 사라질 때 사용)
+func (r *Room) detachSpectators() {
+	for _, client := range r.spectatorClients() {
+		r.removeSpectator(client)
+		client.mu.Lock()
+		client.IsSpectating = false
+		client.Room = nil
+		client.mu.Unlock()
+	}
+}
+
+// 방에 연결된 플레이어 수 조회 (관전자 제외)
+func (r *Room) connectedCount() int {
+	r.membersMu.RLock()
+	defer r.membersMu.RUnlock()
+	return len(r.clients)
+}
+
+// 봇 추가
+func (r *Room) addBot(bot *botPlayer) {
+	r.membersMu.Lock()
+	defer r.membersMu.Unlock()
+	r.bots[bot.id] = bot
+}
+
+// 봇 제거 (botID가 비어있으면 아무 봇이나 제거, 제거할 봇이 없으면 nil)
+func (r *Room) removeBot(botID string) *botPlayer {
+	r.membersMu.Lock()
+	defer r.membersMu.Unlock()
+
+	if botID == "" {
+		for id := range r.bots {
+			botID = id
+			break
+		}
+	}
+	bot, exists := r.bots[botID]
+	if !exists {
+		return nil
+	}
+	delete(r.bots, botID)
+	return bot
+}
+
+// 봇 목록 조회
+func (r *Room) botPlayers() []*botPlayer {
+	r.membersMu.RLock()
+	defer r.membersMu.RUnlock()
+
+	bots := make([]*botPlayer, 0, len(r.bots))
+	for _, bot := range r.bots {
+		bots = append(bots, bot)
+	}
+	return bots
+}
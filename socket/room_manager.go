@@ -0,0 +1,145 @@
+package socket
+
+import (
+	"log"
+	"sort"
+	"sync"
+
+	"main/config"
+)
+
+// 방 관리자 구조체 (여러 방을 동시에 관리)
+type RoomManager struct {
+	mu    sync.RWMutex
+	rooms map[string]*Room
+}
+
+// 새로운 방 관리자 생성
+func NewRoomManager() *RoomManager {
+	return &RoomManager{
+		rooms: make(map[string]*Room),
+	}
+}
+
+// 방 생성 후 등록 (비공개 방은 참여 코드 발급)
+func (m *RoomManager) CreateRoom(name string, isPrivate bool, startPolicy string, gameConfig *config.GameConfig) *Room {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// 중복되지 않는 방 ID 생성
+	id := "room-" + generateRandomCode(8)
+	for {
+		if _, exists := m.rooms[id]; !exists {
+			break
+		}
+		id = "room-" + generateRandomCode(8)
+	}
+
+	if name == "" {
+		name = id
+	}
+
+	room := newRoom(id, name, isPrivate, startPolicy, gameConfig)
+
+	// 중복되지 않는 참여 코드 생성
+	if isPrivate {
+		for {
+			code := generateRandomCode(roomCodeLength)
+			if m.findRoomByCodeLocked(code) == nil {
+				room.code = code
+				break
+			}
+		}
+	}
+
+	m.rooms[id] = room
+	log.Printf("방 생성: %s (%s, 비공개: %v, 시작 방식: %s) (전체 방 개수: %d)", id, name, isPrivate, startPolicy, len(m.rooms))
+	return room
+}
+
+// 방 ID로 방 조회
+func (m *RoomManager) GetRoom(id string) (*Room, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	room, exists := m.rooms[id]
+	return room, exists
+}
+
+// 참여 코드로 방 조회
+func (m *RoomManager) FindRoomByCode(code string) (*Room, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	room := m.findRoomByCodeLocked(code)
+	return room, room != nil
+}
+
+// 참여 코드로 방 조회 (잠금이 이미 잡혀있는 경우를 위한 내부 함수)
+func (m *RoomManager) findRoomByCodeLocked(code string) *Room {
+	if code == "" {
+		return nil
+	}
+	for _, room := range m.rooms {
+		if room.code == code {
+			return room
+		}
+	}
+	return nil
+}
+
+// 방 삭제
+func (m *RoomManager) RemoveRoom(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.rooms[id]; exists {
+		delete(m.rooms, id)
+		log.Printf("방 삭제: %s (전체 방 개수: %d)", id, len(m.rooms))
+	}
+}
+
+// 등록된 모든 방 목록 조회
+func (m *RoomManager) Rooms() []*Room {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	rooms := make([]*Room, 0, len(m.rooms))
+	for _, room := range m.rooms {
+		rooms = append(rooms, room)
+	}
+	return rooms
+}
+
+// 입장 가능한 공개 방 조회 (게임이 시작되지 않았고 자리가 남은 방)
+func (m *RoomManager) FindAvailableRoom() *Room {
+	// 방 잠금과 관리자 잠금이 중첩되지 않도록 목록을 먼저 복사
+	for _, room := range m.Rooms() {
+		if room.isPrivate {
+			continue
+		}
+
+		if room.game.IsJoinable() {
+			return room
+		}
+	}
+	return nil
+}
+
+// 공개 방 목록 정보 조회 (방 목록 응답용)
+func (m *RoomManager) PublicRoomInfos() []RoomInfoData {
+	rooms := m.Rooms()
+	infos := make([]RoomInfoData, 0, len(rooms))
+	for _, room := range rooms {
+		if room.isPrivate {
+			continue
+		}
+		infos = append(infos, room.Info())
+	}
+
+	// 방 ID 순서로 정렬해 목록 순서를 고정
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].RoomID < infos[j].RoomID
+	})
+	return infos
+}
@@ -0,0 +1,134 @@
+package socket
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"main/config"
+)
+
+// 재접속 세션 에러
+var (
+	ErrResumeSessionNotFound = errors.New("존재하지 않는 재접속 토큰입니다")
+	ErrResumeSessionInUse    = errors.New("이미 연결된 세션입니다")
+	ErrResumeSessionExpired  = errors.New("재접속 가능 시간이 지났습니다")
+)
+
+// 재접속 세션 (게임 중 끊긴 연결이 기존 좌석을 되찾을 때 사용)
+type resumeSession struct {
+	token          string
+	clientID       string    // 좌석에 앉은 플레이어 ID
+	accountID      string    // 로그인한 계정 아이디
+	nickname       string    // 로그인한 계정 닉네임
+	sessionToken   string    // 로그인 세션 토큰
+	room           *Room     // 게임 중 연결이 끊긴 방 (연결 중이면 nil)
+	disconnectedAt time.Time // 연결이 끊긴 시간
+	connected      bool      // 현재 연결되어 있는지
+}
+
+// 재접속 세션 저장소
+type resumeSessionStore struct {
+	mu       sync.Mutex
+	sessions map[string]*resumeSession // 토큰 -> 세션
+}
+
+// 새로운 재접속 세션 저장소 생성
+func newResumeSessionStore() *resumeSessionStore {
+	return &resumeSessionStore{
+		sessions: make(map[string]*resumeSession),
+	}
+}
+
+// 클라이언트에게 재접속 토큰 발급
+func (s *resumeSessionStore) issue(clientID string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token := generateResumeToken()
+	for {
+		if _, exists := s.sessions[token]; !exists {
+			break
+		}
+		token = generateResumeToken()
+	}
+
+	s.sessions[token] = &resumeSession{
+		token:     token,
+		clientID:  clientID,
+		connected: true,
+	}
+	return token
+}
+
+// 게임 중 연결 해제 기록 (재접속 대기시간이 지나면 세션 삭제)
+func (s *resumeSessionStore) markDisconnected(token string, room *Room, accountID, nickname, sessionToken string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, exists := s.sessions[token]
+	if !exists {
+		return
+	}
+	session.connected = false
+	session.room = room
+	session.accountID = accountID
+	session.nickname = nickname
+	session.sessionToken = sessionToken
+	session.disconnectedAt = time.Now()
+
+	disconnectedAt := session.disconnectedAt
+	time.AfterFunc(time.Duration(config.ReconnectGracePeriod)*time.Second, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		// 그 사이 재접속했거나 다시 끊긴 경우는 유지
+		if current, exists := s.sessions[token]; exists && !current.connected && current.disconnectedAt.Equal(disconnectedAt) {
+			delete(s.sessions, token)
+			log.Printf("재접속 대기시간 만료: %s", current.clientID)
+		}
+	})
+}
+
+// 재접속 토큰으로 끊긴 세션을 되찾음 (성공 시 다시 연결 상태로 변경)
+func (s *resumeSessionStore) claim(token string) (*resumeSession, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, exists := s.sessions[token]
+	if !exists {
+		return nil, ErrResumeSessionNotFound
+	}
+	if session.connected {
+		return nil, ErrResumeSessionInUse
+	}
+	if time.Since(session.disconnectedAt) > time.Duration(config.ReconnectGracePeriod)*time.Second {
+		delete(s.sessions, token)
+		return nil, ErrResumeSessionExpired
+	}
+
+	session.connected = true
+	claimed := *session
+	session.room = nil
+	return &claimed, nil
+}
+
+// 세션 삭제
+func (s *resumeSessionStore) remove(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, token)
+}
+
+// 재접속 토큰 생성 (추측할 수 없도록 crypto/rand 사용)
+func generateResumeToken() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		log.Printf("재접속 토큰 생성 실패: %v", err)
+		return generateClientID() + generateRandomCode(16)
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,40 @@
+package utils
+
+import "strings"
+
+// 금칙어 필터 (닉네임 검사에 사용, 외부 필터 서비스 등으로 교체 가능)
+type ProfanityFilter interface {
+	Contains(text string) bool
+}
+
+// 금칙어 목록으로 검사하는 기본 필터 (목록이 비어있으면 모두 허용)
+type WordListFilter struct {
+	words []string
+}
+
+// 금칙어 목록 필터 생성 (대소문자와 공백을 무시하고 비교)
+func NewWordListFilter(words []string) *WordListFilter {
+	filter := &WordListFilter{}
+	for _, word := range words {
+		if word = normalizeFilterText(word); word != "" {
+			filter.words = append(filter.words, word)
+		}
+	}
+	return filter
+}
+
+// 문자열에 금칙어가 들어있는지 확인
+func (f *WordListFilter) Contains(text string) bool {
+	text = normalizeFilterText(text)
+	for _, word := range f.words {
+		if strings.Contains(text, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// 비교용 문자열 정규화 (소문자 변환, 공백 제거)
+func normalizeFilterText(text string) string {
+	return strings.Join(strings.Fields(strings.ToLower(text)), "")
+}